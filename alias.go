@@ -0,0 +1,165 @@
+package envconfig
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Deprecation opisuje użycie zmiennej o przestarzałej nazwie z klucza deprecated
+type Deprecation struct {
+	FieldPath   string // Kropkowana ścieżka pola, np. "Database.Host"
+	EnvName     string // Ustawiona zmienna o przestarzałej nazwie, np. "PG_HOST"
+	Replacement string // Bieżąca nazwa zmiennej, np. "DB_HOST"
+}
+
+// String zwraca opis ostrzeżenia
+func (d Deprecation) String() string {
+	return fmt.Sprintf("environment variable %s is deprecated, use %s instead", d.EnvName, d.Replacement)
+}
+
+// log zapisuje ostrzeżenie przez logger lub - dla nil - przez slog.Default()
+func (d Deprecation) log(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Warn(
+		"deprecated environment variable",
+		slog.String("env", d.EnvName),
+		slog.String("replacement", d.Replacement),
+		slog.String("field", d.FieldPath),
+	)
+}
+
+// envAliases zwraca kolejne nazwy zmiennych pola z klucza env (po nazwie głównej)
+// oraz przestarzałe nazwy z klucza deprecated, poprzedzone prefiksem
+func envAliases(tagMap map[string]string, prefix string) ([]string, []string, error) {
+	var aliases, deprecated []string
+	if value, ok := tagMap[EnvKey]; ok && strings.Contains(value, "|") {
+		names, err := splitEnvNames(EnvKey, value, prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		aliases = names[1:]
+	}
+	if value, ok := tagMap[DeprecatedKey]; ok {
+		names, err := splitEnvNames(DeprecatedKey, value, prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		deprecated = names
+	}
+	return aliases, deprecated, nil
+}
+
+// splitEnvNames dzieli listę nazw zmiennych oddzielonych znakiem "|" i dodaje do nich prefiks
+func splitEnvNames(key string, value string, prefix string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(value, "|") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid value %q for key %q: empty variable name", value, key)
+		}
+		names = append(names, prefix+name)
+	}
+	return names, nil
+}
+
+// fieldValue opisuje wartość pola odczytaną ze źródła konfiguracji
+type fieldValue struct {
+	envName  string // Zmienna, z której pochodzi wartość (dla pliku _FILE - nazwa bez sufiksu)
+	value    string
+	source   string // Źródło wartości w raporcie (zob. WithReport)
+	found    bool
+	file     bool // Wartość odczytana z pliku wskazanego przez zmienną z sufiksem _FILE
+	expanded bool // Odwołania ${VAR} w wartości zostały już rozwinięte (np. w pliku .env)
+}
+
+// hasValue sprawdza czy zmienna jest ustawiona na wartość, a nie tylko obecna jako pusty ciąg
+func (v fieldValue) hasValue(allowEmpty bool) bool {
+	return v.found && (v.value != "" || allowEmpty)
+}
+
+// varName zwraca nazwę zmiennej, która dostarczyła wartość - dla pliku jest to zmienna _FILE
+func (v fieldValue) varName() string {
+	if v.file {
+		return v.envName + FileSuffix
+	}
+	return v.envName
+}
+
+// lookupValue szuka wartości pola kolejno w zmiennej envName i jej aliasach - wygrywa
+// pierwsza zmienna z wartością - a jeśli żadna nie ma wartości, w zmiennych przestarzałych.
+// Gdy pole może być odczytane z pliku (files), każda nazwa jest sprawdzana również z sufiksem
+// _FILE, więc plik wskazany dla nazwy bieżącej ma pierwszeństwo przed zmiennymi przestarzałymi.
+//
+// Każda ustawiona zmienna przestarzała jest zgłaszana przez WithDeprecationHandler.
+// Zmienna przestarzała ustawiona na inną wartość niż zmienna bieżąca (lub jej plik _FILE)
+// jest błędem *ConflictError.
+func (l *loader) lookupValue(envName string, aliases, deprecated []string, fieldPath string, allowEmpty, files bool) (fieldValue, error) {
+	// Zmienna ustawiona na pusty ciąg jest używana tylko wtedy, gdy żadna inna nie ma wartości
+	v := fieldValue{envName: envName}
+	for _, alias := range append([]string{envName}, aliases...) {
+		candidate, err := l.lookupVar(alias, allowEmpty, files)
+		if err != nil {
+			return fieldValue{}, err
+		}
+		if candidate.hasValue(allowEmpty) || (candidate.found && !v.found) {
+			v = candidate
+		}
+		if candidate.hasValue(allowEmpty) {
+			break
+		}
+	}
+
+	for _, old := range deprecated {
+		candidate, err := l.lookupVar(old, allowEmpty, files)
+		if err != nil {
+			return fieldValue{}, err
+		}
+		if !candidate.hasValue(allowEmpty) {
+			continue
+		}
+		if v.hasValue(allowEmpty) && candidate.value != v.value {
+			return fieldValue{}, &ConflictError{EnvNames: []string{v.varName(), candidate.varName()}}
+		}
+		replacement := envName
+		if candidate.file {
+			replacement += FileSuffix
+		}
+		l.deprecated(Deprecation{FieldPath: fieldPath, EnvName: candidate.varName(), Replacement: replacement})
+		if !v.hasValue(allowEmpty) {
+			v = candidate
+		}
+	}
+	return v, nil
+}
+
+// lookupVar pobiera wartość pojedynczej zmiennej, a gdy pole może być odczytane z pliku -
+// również zawartość pliku wskazanego przez zmienną z sufiksem _FILE (zob. lookupFile)
+func (l *loader) lookupVar(name string, allowEmpty, files bool) (fieldValue, error) {
+	value, source, ok := lookupSource(l.opts.lookuper, name)
+	_, expanded, _ := lookupExpanded(l.opts.lookuper, name)
+	v := fieldValue{envName: name, value: value, source: source, found: ok, expanded: expanded}
+	if !files {
+		return v, nil
+	}
+
+	fileValue, path, fileFound, err := l.lookupFile(name, v.hasValue(allowEmpty))
+	if err != nil {
+		return fieldValue{}, err
+	}
+	if fileFound {
+		return fieldValue{envName: name, value: fileValue, source: path, found: true, file: true}, nil
+	}
+	return v, nil
+}
+
+// deprecated zgłasza użycie zmiennej o przestarzałej nazwie
+func (l *loader) deprecated(d Deprecation) {
+	if l.opts.deprecation != nil {
+		l.opts.deprecation(d)
+		return
+	}
+	d.log(nil)
+}
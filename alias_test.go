@@ -0,0 +1,234 @@
+package envconfig
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// aliasConfig jest konfiguracją z aliasami i przestarzałymi nazwami zmiennych używaną w testach
+type aliasConfig struct {
+	Host string `envconfig:"env=DB_HOST|DATABASE_HOST,deprecated=PG_HOST|POSTGRES_HOST"`
+	Port int    `envconfig:"env=DB_PORT,deprecated=PG_PORT,default=5432"`
+}
+
+// TestLoadStruct_Aliases sprawdza kolejność nazw zmiennych i zgłaszanie przestarzałych nazw
+func TestLoadStruct_Aliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		values         map[string]string
+		wantHost       string
+		wantPort       int
+		wantDeprecated []string
+	}{
+		{
+			name:     "primary name",
+			values:   map[string]string{"DB_HOST": "db", "DATABASE_HOST": "other"},
+			wantHost: "db",
+			wantPort: 5432,
+		},
+		{
+			name:     "alias",
+			values:   map[string]string{"DB_HOST": "", "DATABASE_HOST": "other"},
+			wantHost: "other",
+			wantPort: 5432,
+		},
+		{
+			name:           "deprecated names",
+			values:         map[string]string{"POSTGRES_HOST": "legacy", "PG_PORT": "6432"},
+			wantHost:       "legacy",
+			wantPort:       6432,
+			wantDeprecated: []string{"POSTGRES_HOST->DB_HOST", "PG_PORT->DB_PORT"},
+		},
+		{
+			name:           "deprecated name with the same value",
+			values:         map[string]string{"DATABASE_HOST": "db", "PG_HOST": "db"},
+			wantHost:       "db",
+			wantPort:       5432,
+			wantDeprecated: []string{"PG_HOST->DB_HOST"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var deprecated []string
+				handler := func(d Deprecation) {
+					deprecated = append(deprecated, d.EnvName+"->"+d.Replacement)
+				}
+
+				var cfg aliasConfig
+				err := LoadWith(&cfg, WithLookuper(MapLookuper(tt.values)), WithDeprecationHandler(handler))
+				if err != nil {
+					t.Fatalf("LoadWith() error = %v", err)
+				}
+				if cfg.Host != tt.wantHost || cfg.Port != tt.wantPort {
+					t.Errorf("LoadWith() = %+v, want Host=%s Port=%d", cfg, tt.wantHost, tt.wantPort)
+				}
+				if !reflect.DeepEqual(deprecated, tt.wantDeprecated) {
+					t.Errorf("deprecated = %q, want %q", deprecated, tt.wantDeprecated)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_AliasConflict sprawdza błąd przy różnych wartościach zmiennej bieżącej i przestarzałej
+func TestLoadStruct_AliasConflict(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Database aliasConfig `envconfig:"prefix=APP_"`
+	}
+
+	var cfg Config
+	source := MapLookuper(map[string]string{"APP_DATABASE_HOST": "db", "APP_PG_HOST": "legacy"})
+	err := LoadWith(&cfg, WithLookuper(source), WithDeprecationHandler(func(Deprecation) {}))
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("LoadWith() error type = %T, want *ConflictError", err)
+	}
+	want := &ConflictError{
+		FieldName: "Host",
+		FieldPath: "Database.Host",
+		EnvNames:  []string{"APP_DATABASE_HOST", "APP_PG_HOST"},
+	}
+	if !reflect.DeepEqual(conflictErr, want) {
+		t.Errorf("ConflictError = %+v, want %+v", conflictErr, want)
+	}
+}
+
+// TestLoadStruct_AliasFiles sprawdza, że zmienne _FILE nazw bieżących mają pierwszeństwo
+// przed zmiennymi przestarzałymi
+func TestLoadStruct_AliasFiles(t *testing.T) {
+	t.Parallel()
+
+	path := writeSecret(t, "host", "db.local\n")
+
+	tests := []struct {
+		name           string
+		values         map[string]string
+		wantHost       string
+		wantConflict   []string
+		wantDeprecated []string
+	}{
+		{
+			name:     "current file",
+			values:   map[string]string{"DB_HOST_FILE": path},
+			wantHost: "db.local",
+		},
+		{
+			name:     "alias file",
+			values:   map[string]string{"DATABASE_HOST_FILE": path},
+			wantHost: "db.local",
+		},
+		{
+			name:           "deprecated file",
+			values:         map[string]string{"PG_HOST_FILE": path},
+			wantHost:       "db.local",
+			wantDeprecated: []string{"PG_HOST_FILE->DB_HOST_FILE"},
+		},
+		{
+			name:         "deprecated value and current file",
+			values:       map[string]string{"PG_HOST": "legacy", "DB_HOST_FILE": path},
+			wantConflict: []string{"DB_HOST_FILE", "PG_HOST"},
+		},
+		{
+			name:         "deprecated value and alias file",
+			values:       map[string]string{"POSTGRES_HOST": "legacy", "DATABASE_HOST_FILE": path},
+			wantConflict: []string{"DATABASE_HOST_FILE", "POSTGRES_HOST"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var deprecated []string
+				handler := func(d Deprecation) {
+					deprecated = append(deprecated, d.EnvName+"->"+d.Replacement)
+				}
+
+				var cfg aliasConfig
+				err := LoadWith(&cfg, WithLookuper(MapLookuper(tt.values)), WithFileValues(), WithDeprecationHandler(handler))
+				if tt.wantConflict != nil {
+					var conflictErr *ConflictError
+					if !errors.As(err, &conflictErr) {
+						t.Fatalf("LoadWith() error type = %T, want *ConflictError", err)
+					}
+					if !reflect.DeepEqual(conflictErr.EnvNames, tt.wantConflict) {
+						t.Errorf("ConflictError.EnvNames = %v, want %v", conflictErr.EnvNames, tt.wantConflict)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("LoadWith() error = %v", err)
+				}
+				if cfg.Host != tt.wantHost {
+					t.Errorf("Host = %q, want %q", cfg.Host, tt.wantHost)
+				}
+				if !reflect.DeepEqual(deprecated, tt.wantDeprecated) {
+					t.Errorf("deprecated = %v, want %v", deprecated, tt.wantDeprecated)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_AliasReport sprawdza, że raport i opis wskazują właściwe nazwy zmiennych
+func TestLoadStruct_AliasReport(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	var cfg aliasConfig
+	var report Report
+	source := MapLookuper(map[string]string{"PG_HOST": "legacy"})
+	if err := LoadWith(&cfg, WithLookuper(source), WithReport(&report), WithLogger(logger)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+
+	if field, ok := report.Field("Host"); !ok || field.EnvName != "PG_HOST" || field.Source != SourceEnv {
+		t.Errorf("Report.Field(Host) = %+v", field)
+	}
+	want := `level=WARN msg="deprecated environment variable" env=PG_HOST replacement=DB_HOST field=Host`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("log output = %q, want it to contain %q", buf.String(), want)
+	}
+
+	desc, err := Describe(&cfg)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if desc[0].EnvName != "DB_HOST" {
+		t.Errorf("Describe() EnvName = %s, want DB_HOST", desc[0].EnvName)
+	}
+
+	type Invalid struct {
+		Field string `envconfig:"env=A||B"`
+	}
+	err = LoadWith(&Invalid{}, WithLookuper(MapLookuper(nil)))
+	var tagErr *TagSyntaxError
+	if !errors.As(err, &tagErr) || !strings.Contains(tagErr.Msg, `invalid value "A||B" for key "env": empty variable name`) {
+		t.Errorf("LoadWith() error = %v, want TagSyntaxError", err)
+	}
+}
+
+// TestDeprecation_String sprawdza opis ostrzeżenia
+func TestDeprecation_String(t *testing.T) {
+	t.Parallel()
+
+	d := Deprecation{FieldPath: "Database.Host", EnvName: "PG_HOST", Replacement: "DB_HOST"}
+	if want := "environment variable PG_HOST is deprecated, use DB_HOST instead"; d.String() != want {
+		t.Errorf("String() = %q, want %q", d.String(), want)
+	}
+}
@@ -0,0 +1,200 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// setListValue ustawia wartość pola typu slice lub tablica.
+// Wartość jest dzielona na elementy separatorem z klucza separator (domyślnie ","),
+// a każdy element jest parsowany tak jak pole skalarne odpowiedniego typu.
+// Separator poprzedzony znakiem "\" nie dzieli wartości, a "\\" oznacza pojedynczy "\".
+// Błąd parsowania elementu zawiera jego indeks w nazwie pola, np. "Ports[2]".
+func setListValue(field reflect.Value, value string, fieldName string, tagMap map[string]string) error {
+	// []byte traktujemy jako surowe bajty wartości, a nie listę liczb
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 {
+		field.SetBytes([]byte(value))
+		return nil
+	}
+
+	separator, ok := tagMap[SeparatorKey]
+	if !ok || separator == "" {
+		separator = DefaultSeparator
+	}
+
+	// Pusta wartość oznacza pustą listę, a nie listę z jednym pustym elementem
+	var items []string
+	if value != "" {
+		items = splitEscaped(value, separator)
+	}
+
+	var list reflect.Value
+	if field.Kind() == reflect.Array {
+		if len(items) > field.Len() {
+			return &ParseError{
+				FieldName: fieldName,
+				FieldType: field.Type().String(),
+				Value:     value,
+				Err:       fmt.Errorf("too many elements: got %d, want at most %d", len(items), field.Len()),
+			}
+		}
+		// Pracujemy na kopii, aby przy błędzie nie zostawić częściowo wypełnionej tablicy
+		list = reflect.New(field.Type()).Elem()
+	} else {
+		list = reflect.MakeSlice(field.Type(), len(items), len(items))
+	}
+
+	for i, item := range items {
+		if err := setFieldValue(list.Index(i), item, fmt.Sprintf("%s[%d]", fieldName, i)); err != nil {
+			return err
+		}
+	}
+
+	field.Set(list)
+	return nil
+}
+
+// setMapValue ustawia wartość pola typu mapa.
+// Wartość jest dzielona na pary separatorem z klucza separator (domyślnie ","),
+// a każda para na klucz i wartość separatorem z klucza kvSeparator (domyślnie ":").
+// Oba separatory można poprzedzić znakiem "\", aby stały się częścią klucza lub wartości.
+// Błędy wskazują klucz, którego dotyczą, np. "Limits[acme]".
+func setMapValue(field reflect.Value, value string, fieldName string, tagMap map[string]string) error {
+	separator, ok := tagMap[SeparatorKey]
+	if !ok || separator == "" {
+		separator = DefaultSeparator
+	}
+	kvSeparator, ok := tagMap[KeyValueSeparatorKey]
+	if !ok || kvSeparator == "" {
+		kvSeparator = DefaultKeyValueSeparator
+	}
+
+	mapType := field.Type()
+	result := reflect.MakeMap(mapType)
+
+	// Pusta wartość oznacza pustą mapę
+	if value == "" {
+		field.Set(result)
+		return nil
+	}
+
+	for _, pair := range splitRaw(value, separator) {
+		rawKey, rawValue, found := cutRaw(pair, kvSeparator)
+		if !found {
+			return &ParseError{
+				FieldName: fieldName,
+				FieldType: mapType.String(),
+				Value:     strings.TrimSpace(pair),
+				Err:       fmt.Errorf("missing key/value separator %q", kvSeparator),
+			}
+		}
+
+		keyText := strings.TrimSpace(unescape(rawKey, separator, kvSeparator))
+		valueText := strings.TrimSpace(unescape(rawValue, separator, kvSeparator))
+		elemName := fmt.Sprintf("%s[%s]", fieldName, keyText)
+
+		key := reflect.New(mapType.Key()).Elem()
+		if err := setFieldValue(key, keyText, elemName); err != nil {
+			return err
+		}
+		if result.MapIndex(key).IsValid() {
+			return &ParseError{
+				FieldName: elemName,
+				FieldType: mapType.String(),
+				Value:     keyText,
+				Err:       errors.New("duplicate key"),
+			}
+		}
+
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := setFieldValue(elem, valueText, elemName); err != nil {
+			return err
+		}
+		result.SetMapIndex(key, elem)
+	}
+
+	field.Set(result)
+	return nil
+}
+
+// splitEscaped dzieli wartość separatorem z pominięciem separatorów poprzedzonych znakiem "\".
+// Sekwencje "\<separator>" i "\\" są zamieniane odpowiednio na separator i "\",
+// pozostałe znaki "\" są zachowywane bez zmian. Białe znaki wokół elementów są usuwane.
+func splitEscaped(value string, separator string) []string {
+	items := splitRaw(value, separator)
+	for i, item := range items {
+		items[i] = strings.TrimSpace(unescape(item, separator))
+	}
+	return items
+}
+
+// splitRaw dzieli wartość separatorem z pominięciem separatorów poprzedzonych znakiem "\".
+// W przeciwieństwie do splitEscaped nie usuwa znaków ucieczki, dzięki czemu
+// elementy można dalej dzielić innym separatorem.
+func splitRaw(value string, separator string) []string {
+	var items []string
+	for {
+		before, after, found := cutRaw(value, separator)
+		items = append(items, before)
+		if !found {
+			return items
+		}
+		value = after
+	}
+}
+
+// cutRaw dzieli wartość przy pierwszym separatorze niepoprzedzonym znakiem "\".
+// Znaki ucieczki pozostają w zwracanych częściach.
+func cutRaw(value string, separator string) (before, after string, found bool) {
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			// Pomijamy znak następujący po "\" (w tym cały separator)
+			if strings.HasPrefix(value[i+1:], separator) {
+				i += len(separator)
+			} else {
+				i++
+			}
+			continue
+		}
+		if strings.HasPrefix(value[i:], separator) {
+			return value[:i], value[i+len(separator):], true
+		}
+	}
+	return value, "", false
+}
+
+// unescape zamienia sekwencje "\<separator>" na separator i "\\" na "\".
+// Pozostałe znaki "\" są zachowywane bez zmian.
+func unescape(value string, separators ...string) string {
+	if !strings.Contains(value, "\\") {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			rest := value[i+1:]
+			if rest[0] == '\\' {
+				b.WriteByte('\\')
+				i++
+				continue
+			}
+			escaped := false
+			for _, separator := range separators {
+				if strings.HasPrefix(rest, separator) {
+					b.WriteString(separator)
+					i += len(separator)
+					escaped = true
+					break
+				}
+			}
+			if escaped {
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,311 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSplitEscaped sprawdza dzielenie wartości z obsługą znaków ucieczki
+func TestSplitEscaped(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		separator string
+		expected  []string
+	}{
+		{
+			name:      "Simple list",
+			value:     "a,b,c",
+			separator: ",",
+			expected:  []string{"a", "b", "c"},
+		},
+		{
+			name:      "Whitespace handling",
+			value:     " a , b ,c ",
+			separator: ",",
+			expected:  []string{"a", "b", "c"},
+		},
+		{
+			name:      "Escaped separator",
+			value:     `a\,b,c`,
+			separator: ",",
+			expected:  []string{"a,b", "c"},
+		},
+		{
+			name:      "Escaped backslash",
+			value:     `a\\,b`,
+			separator: ",",
+			expected:  []string{`a\`, "b"},
+		},
+		{
+			name:      "Other backslashes are kept",
+			value:     `C:\dir;D:\other`,
+			separator: ";",
+			expected:  []string{`C:\dir`, `D:\other`},
+		},
+		{
+			name:      "Multi-character separator",
+			value:     "a::b\\::c::d",
+			separator: "::",
+			expected:  []string{"a", "b::c", "d"},
+		},
+		{
+			name:      "Empty elements",
+			value:     "a,,b,",
+			separator: ",",
+			expected:  []string{"a", "", "b", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result := splitEscaped(tt.value, tt.separator)
+				if !reflect.DeepEqual(result, tt.expected) {
+					t.Errorf("splitEscaped() = %q, want %q", result, tt.expected)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_Lists sprawdza ładowanie pól typu slice i tablica
+func TestLoadStruct_Lists(t *testing.T) {
+	t.Parallel()
+
+	source := WithLookuper(MapLookuper(map[string]string{
+		"ORIGINS":   "http://a.com,http://b.com",
+		"PORTS":     "80;443",
+		"TIMEOUTS":  "1s,1m",
+		"DATES":     "2023-01-02T15:04:05Z",
+		"FLAGS":     "true,false,true",
+		"WEIGHTS":   "0.5, 1.5",
+		"EMPTY":     "",
+		"RAW":       "bytes",
+		"BAD_PORTS": "80,abc",
+		"TOO_MANY":  "1,2,3",
+	}))
+
+	t.Run(
+		"Supported element types", func(t *testing.T) {
+			type Config struct {
+				Origins  []string        `envconfig:"env=ORIGINS"`
+				Ports    []int           `envconfig:"env=PORTS,separator=;"`
+				Timeouts []time.Duration `envconfig:"env=TIMEOUTS"`
+				Dates    []time.Time     `envconfig:"env=DATES"`
+				Flags    [3]bool         `envconfig:"env=FLAGS"`
+				Weights  [4]float64      `envconfig:"env=WEIGHTS"`
+				Raw      []byte          `envconfig:"env=RAW"`
+				Defaults []uint          `envconfig:"env=MISSING,default=1|2|3,separator=|"`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(cfg.Origins, []string{"http://a.com", "http://b.com"}) {
+				t.Errorf("Origins = %v", cfg.Origins)
+			}
+			if !reflect.DeepEqual(cfg.Ports, []int{80, 443}) {
+				t.Errorf("Ports = %v", cfg.Ports)
+			}
+			if !reflect.DeepEqual(cfg.Timeouts, []time.Duration{time.Second, time.Minute}) {
+				t.Errorf("Timeouts = %v", cfg.Timeouts)
+			}
+			expectedTime, _ := time.Parse(time.RFC3339, "2023-01-02T15:04:05Z")
+			if len(cfg.Dates) != 1 || !cfg.Dates[0].Equal(expectedTime) {
+				t.Errorf("Dates = %v", cfg.Dates)
+			}
+			if cfg.Flags != [3]bool{true, false, true} {
+				t.Errorf("Flags = %v", cfg.Flags)
+			}
+			if cfg.Weights != [4]float64{0.5, 1.5, 0, 0} {
+				t.Errorf("Weights = %v", cfg.Weights)
+			}
+			if string(cfg.Raw) != "bytes" {
+				t.Errorf("Raw = %v", cfg.Raw)
+			}
+			if !reflect.DeepEqual(cfg.Defaults, []uint{1, 2, 3}) {
+				t.Errorf("Defaults = %v", cfg.Defaults)
+			}
+		},
+	)
+
+	t.Run(
+		"Empty value gives empty slice", func(t *testing.T) {
+			type Config struct {
+				Empty []string `envconfig:"env=EMPTY,allowEmpty=true"`
+			}
+
+			cfg := Config{Empty: []string{"old"}}
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if len(cfg.Empty) != 0 {
+				t.Errorf("Empty = %v, want empty slice", cfg.Empty)
+			}
+		},
+	)
+
+	t.Run(
+		"Invalid element", func(t *testing.T) {
+			type Config struct {
+				Ports []int `envconfig:"env=BAD_PORTS"`
+			}
+
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("LoadStruct() error type = %T, want *ParseError", err)
+			}
+			if parseErr.FieldName != "Ports[1]" {
+				t.Errorf("ParseError.FieldName = %v, want %v", parseErr.FieldName, "Ports[1]")
+			}
+			if parseErr.Value != "abc" {
+				t.Errorf("ParseError.Value = %v, want %v", parseErr.Value, "abc")
+			}
+		},
+	)
+
+	t.Run(
+		"Too many array elements", func(t *testing.T) {
+			type Config struct {
+				Values [2]int `envconfig:"env=TOO_MANY"`
+			}
+
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("LoadStruct() error type = %T, want *ParseError", err)
+			}
+			if parseErr.FieldType != "[2]int" {
+				t.Errorf("ParseError.FieldType = %v, want %v", parseErr.FieldType, "[2]int")
+			}
+			if cfg.Values != [2]int{} {
+				t.Errorf("Values = %v, want zero array", cfg.Values)
+			}
+		},
+	)
+
+	t.Run(
+		"Unsupported element type", func(t *testing.T) {
+			type Config struct {
+				Values [][]int `envconfig:"env=ORIGINS"`
+			}
+
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+			if !errors.Is(err, ErrUnsupportedFieldType) {
+				t.Errorf("LoadStruct() error = %v, want %v", err, ErrUnsupportedFieldType)
+			}
+		},
+	)
+}
+
+// TestLoadStruct_Maps sprawdza ładowanie pól typu mapa
+func TestLoadStruct_Maps(t *testing.T) {
+	t.Parallel()
+
+	source := WithLookuper(MapLookuper(map[string]string{
+		"TENANT_LIMITS": "acme:100, globex:250",
+		"LABELS":        `team=core;query=a\=b;empty=`,
+		"TIMEOUTS":      "1:1s,2:1m",
+	}))
+
+	t.Run(
+		"Supported key and value types", func(t *testing.T) {
+			type Config struct {
+				Limits   map[string]int          `envconfig:"env=TENANT_LIMITS"`
+				Labels   map[string]string       `envconfig:"env=LABELS,separator=;,kvSeparator=="`
+				Timeouts map[uint8]time.Duration `envconfig:"env=TIMEOUTS"`
+				Defaults map[string]bool         `envconfig:"env=MISSING,default=a:true|b:false,separator=|"`
+				Empty    map[string]string       `envconfig:"env=MISSING,default="`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(cfg.Limits, map[string]int{"acme": 100, "globex": 250}) {
+				t.Errorf("Limits = %v", cfg.Limits)
+			}
+			if !reflect.DeepEqual(cfg.Labels, map[string]string{"team": "core", "query": "a=b", "empty": ""}) {
+				t.Errorf("Labels = %v", cfg.Labels)
+			}
+			if !reflect.DeepEqual(cfg.Timeouts, map[uint8]time.Duration{1: time.Second, 2: time.Minute}) {
+				t.Errorf("Timeouts = %v", cfg.Timeouts)
+			}
+			if !reflect.DeepEqual(cfg.Defaults, map[string]bool{"a": true, "b": false}) {
+				t.Errorf("Defaults = %v", cfg.Defaults)
+			}
+			if cfg.Empty == nil || len(cfg.Empty) != 0 {
+				t.Errorf("Empty = %v, want empty map", cfg.Empty)
+			}
+		},
+	)
+
+	tests := []struct {
+		name      string
+		fieldType reflect.Type
+		value     string
+		wantField string
+		wantValue string
+	}{
+		{
+			name:      "Invalid value",
+			fieldType: reflect.TypeOf(map[string]int{}),
+			value:     "acme:100,globex:many",
+			wantField: "Values[globex]",
+			wantValue: "many",
+		},
+		{
+			name:      "Invalid key",
+			fieldType: reflect.TypeOf(map[int]int{}),
+			value:     "1:2,two:3",
+			wantField: "Values[two]",
+			wantValue: "two",
+		},
+		{
+			name:      "Missing key/value separator",
+			fieldType: reflect.TypeOf(map[string]int{}),
+			value:     "acme",
+			wantField: "Values",
+			wantValue: "acme",
+		},
+		{
+			name:      "Duplicate key",
+			fieldType: reflect.TypeOf(map[string]int{}),
+			value:     "a:1,a:2",
+			wantField: "Values[a]",
+			wantValue: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				field := reflect.New(tt.fieldType).Elem()
+				err := setMapValue(field, tt.value, "Values", nil)
+
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("setMapValue() error type = %T, want *ParseError", err)
+				}
+				if parseErr.FieldName != tt.wantField {
+					t.Errorf("ParseError.FieldName = %v, want %v", parseErr.FieldName, tt.wantField)
+				}
+				if parseErr.Value != tt.wantValue {
+					t.Errorf("ParseError.Value = %v, want %v", parseErr.Value, tt.wantValue)
+				}
+			},
+		)
+	}
+}
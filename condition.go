@@ -0,0 +1,170 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// conditionKeys zawiera klucze tagu określające warunki wymagania lub wykluczenia pola,
+// w kolejności ich sprawdzania
+var conditionKeys = []string{RequiredIfKey, RequiredUnlessKey, RequiredWithKey, ExcludedWithKey}
+
+// condition jest pojedynczym warunkiem z tagu pola, odwołującym się do innych pól tej samej struktury
+type condition struct {
+	key    string                   // Klucz tagu, np. "required_if"
+	fields []int                    // Indeksy pól struktury, do których odwołuje się warunek
+	names  []string                 // Nazwy tych pól
+	values []string                 // Wartości z warunku required_if i required_unless
+	match  func(reflect.Value) bool // Sprawdza czy wartość pola jest jedną z wartości warunku
+}
+
+// conditional opisuje pole z warunkami sprawdzanymi po załadowaniu całej struktury
+type conditional struct {
+	index      int    // Indeks pola w strukturze
+	name       string // Nazwa pola Go
+	path       string // Pełna ścieżka pola
+	envName    string
+	conditions []condition
+}
+
+// parseConditions tworzy warunki z tagu pola struktury structType:
+//
+//   - required_if=POLE:wartość - pole jest wymagane, gdy POLE ma podaną wartość
+//   - required_unless=POLE:wartość - pole jest wymagane, chyba że POLE ma podaną wartość
+//   - required_with=POLE1|POLE2 - pole jest wymagane, gdy zmienna któregokolwiek z pól jest ustawiona
+//   - excluded_with=POLE1|POLE2 - zmienna pola nie może być ustawiona, gdy ustawiona jest zmienna
+//     któregokolwiek z pól
+//
+// POLE jest nazwą Go innego pola tej samej struktury. Wartości required_if i required_unless
+// mogą być oddzielone znakiem "|" i są parsowane tak jak wartość wskazanego pola.
+func parseConditions(structType reflect.Type, tagMap map[string]string) ([]condition, error) {
+	var conditions []condition
+	for _, key := range conditionKeys {
+		arg, ok := tagMap[key]
+		if !ok {
+			continue
+		}
+
+		c := condition{key: key}
+		switch key {
+		case RequiredIfKey, RequiredUnlessKey:
+			name, values, found := strings.Cut(arg, ":")
+			if !found {
+				return nil, fmt.Errorf("invalid value %q for key %q: expected FIELD:value", arg, key)
+			}
+			index, t, err := conditionField(structType, key, strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			if c.match, err = oneOfCheck(validatedType(t), key, values); err != nil {
+				return nil, err
+			}
+			c.fields = []int{index}
+			c.names = []string{strings.TrimSpace(name)}
+			for _, value := range strings.Split(values, "|") {
+				c.values = append(c.values, strings.TrimSpace(value))
+			}
+		case RequiredWithKey, ExcludedWithKey:
+			for _, name := range strings.Split(arg, "|") {
+				name = strings.TrimSpace(name)
+				index, _, err := conditionField(structType, key, name)
+				if err != nil {
+					return nil, err
+				}
+				c.fields = append(c.fields, index)
+				c.names = append(c.names, name)
+			}
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// conditionField zwraca indeks i typ pola, do którego odwołuje się warunek. Pole musi być
+// eksportowanym polem tej samej struktury, ładowanym z pojedynczej zmiennej.
+func conditionField(structType reflect.Type, key string, name string) (int, reflect.Type, error) {
+	field, ok := structType.FieldByName(name)
+	if !ok || len(field.Index) != 1 || !field.IsExported() {
+		return 0, nil, fmt.Errorf("key %q refers to unknown field %q", key, name)
+	}
+	if isNestedStruct(field.Type) || isNestedStructPtr(field.Type) {
+		return 0, nil, fmt.Errorf("key %q refers to nested struct %q", key, name)
+	}
+	return field.Index[0], field.Type, nil
+}
+
+// trigger sprawdza czy warunek jest spełniony i zwraca jego opis do komunikatu błędu.
+// sourced wskazuje pola struktury, których zmienne są ustawione - wartość domyślna
+// nie sprawia, że pole jest ustawione dla required_with i excluded_with.
+func (c condition) trigger(structValue reflect.Value, path string, sourced []bool) (string, bool) {
+	switch c.key {
+	case RequiredIfKey, RequiredUnlessKey:
+		value, ok := validatedValue(structValue.Field(c.fields[0]))
+		matched := ok && c.match(value)
+		if matched != (c.key == RequiredIfKey) {
+			return "", false
+		}
+		when := "when"
+		if c.key == RequiredUnlessKey {
+			when = "unless"
+		}
+		values := "'" + c.values[0] + "'"
+		if len(c.values) > 1 {
+			values = "one of '" + strings.Join(c.values, "', '") + "'"
+		}
+		return fmt.Sprintf("%s '%s' is %s", when, joinPath(path, c.names[0]), values), true
+	default:
+		for i, index := range c.fields {
+			if sourced[index] {
+				return fmt.Sprintf("when '%s' is set", joinPath(path, c.names[i])), true
+			}
+		}
+		return "", false
+	}
+}
+
+// checkConditions sprawdza warunki pól struktury po załadowaniu wszystkich jej pól.
+// Pomijane są pola, których ładowanie się nie powiodło (zob. errorMarks), i warunki
+// odwołujące się do takich pól. Dla pola zgłaszany jest co najwyżej jeden błąd.
+//
+// Wymagane pole spełnia warunek również wartością domyślną (set), natomiast pole
+// z excluded_with narusza warunek tylko wtedy, gdy jego zmienna jest ustawiona (sourced).
+func (l *loader) checkConditions(structValue reflect.Value, sc scope, pending []conditional, set, sourced []bool, marks errorMarks) {
+	for _, f := range pending {
+		if marks.failed(f.index) {
+			continue
+		}
+	conditions:
+		for _, c := range f.conditions {
+			for _, index := range c.fields {
+				if marks.failed(index) {
+					continue conditions
+				}
+			}
+
+			when, ok := c.trigger(structValue, sc.path, sourced)
+			if !ok {
+				continue
+			}
+			if c.key == ExcludedWithKey && sourced[f.index] {
+				l.fail(&ExcludedFieldError{
+					FieldName: f.name,
+					FieldPath: f.path,
+					EnvName:   f.envName,
+					Condition: when,
+				})
+				break
+			}
+			if c.key != ExcludedWithKey && !set[f.index] {
+				l.fail(&RequiredFieldError{
+					FieldName: f.name,
+					FieldPath: f.path,
+					EnvName:   f.envName,
+					Condition: when,
+				})
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package envconfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// storageConfig jest konfiguracją z warunkowo wymaganymi polami używaną w testach
+type storageConfig struct {
+	Storage  string `envconfig:"env=STORAGE,default=local"`
+	Bucket   string `envconfig:"env=S3_BUCKET,required_if=Storage:s3|gcs"`
+	Path     string `envconfig:"env=DATA_PATH,required_unless=Storage:s3|gcs"`
+	Replicas int    `envconfig:"env=REPLICAS"`
+	Quorum   int    `envconfig:"env=QUORUM,required_if=Replicas:3"`
+	Cert     string `envconfig:"env=TLS_CERT,required_with=Key"`
+	Key      string `envconfig:"env=TLS_KEY,required_with=Cert"`
+	Insecure bool   `envconfig:"env=INSECURE,excluded_with=Cert|Key"`
+}
+
+// TestLoadStruct_Conditions sprawdza warunki required_if, required_unless, required_with i excluded_with
+func TestLoadStruct_Conditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{
+			name:   "local storage",
+			values: map[string]string{"DATA_PATH": "/data"},
+		},
+		{
+			name:   "s3 storage",
+			values: map[string]string{"STORAGE": "s3", "S3_BUCKET": "backups"},
+		},
+		{
+			name:    "s3 storage without bucket",
+			values:  map[string]string{"STORAGE": "s3"},
+			wantErr: "missing required field: field 'Bucket' is required when 'Storage' is one of 's3', 'gcs' but no value was provided (env: S3_BUCKET)",
+		},
+		{
+			name:    "local storage without path",
+			values:  map[string]string{},
+			wantErr: "missing required field: field 'Path' is required unless 'Storage' is one of 's3', 'gcs' but no value was provided (env: DATA_PATH)",
+		},
+		{
+			name:    "values are compared after parsing",
+			values:  map[string]string{"DATA_PATH": "/data", "REPLICAS": "03"},
+			wantErr: "missing required field: field 'Quorum' is required when 'Replicas' is '3' but no value was provided (env: QUORUM)",
+		},
+		{
+			name:   "certificate with key",
+			values: map[string]string{"DATA_PATH": "/data", "TLS_CERT": "cert.pem", "TLS_KEY": "key.pem"},
+		},
+		{
+			name:    "certificate without key",
+			values:  map[string]string{"DATA_PATH": "/data", "TLS_CERT": "cert.pem"},
+			wantErr: "missing required field: field 'Key' is required when 'Cert' is set but no value was provided (env: TLS_KEY)",
+		},
+		{
+			name:   "insecure without certificate",
+			values: map[string]string{"DATA_PATH": "/data", "INSECURE": "true"},
+		},
+		{
+			name:    "insecure with key",
+			values:  map[string]string{"DATA_PATH": "/data", "TLS_CERT": "cert.pem", "TLS_KEY": "key.pem", "INSECURE": "false"},
+			wantErr: "excluded field: field 'Insecure' must not be set when 'Cert' is set (env: INSECURE)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var cfg storageConfig
+				err := LoadWith(&cfg, WithLookuper(MapLookuper(tt.values)))
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Fatalf("LoadWith() error = %v", err)
+					}
+					return
+				}
+
+				var loadErrs LoadErrors
+				if !errors.As(err, &loadErrs) || len(loadErrs) != 1 {
+					t.Fatalf("LoadWith() error = %v, want 1 error", err)
+				}
+				if loadErrs[0].Error() != tt.wantErr {
+					t.Errorf("LoadWith() error =\n%s\nwant\n%s", loadErrs[0], tt.wantErr)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_ConditionErrors sprawdza typy błędów warunków i pomijanie pól z błędami
+func TestLoadStruct_ConditionErrors(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Server struct {
+			Mode  string `envconfig:"env=MODE"`
+			Port  int    `envconfig:"env=PORT"`
+			Token string `envconfig:"env=TOKEN,required_if=Mode:auth"`
+			Debug bool   `envconfig:"env=DEBUG,excluded_with=Token,required_with=Port"`
+		}
+	}
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"MODE": "auth", "TOKEN": "t", "DEBUG": "1"})))
+	var excludedErr *ExcludedFieldError
+	if !errors.As(err, &excludedErr) {
+		t.Fatalf("LoadWith() error type = %T, want *ExcludedFieldError", err)
+	}
+	if excludedErr.FieldPath != "Server.Debug" || excludedErr.Condition != "when 'Server.Token' is set" {
+		t.Errorf("ExcludedFieldError = %+v", excludedErr)
+	}
+	if !errors.Is(err, ErrExcludedField) {
+		t.Error("error does not match ErrExcludedField")
+	}
+
+	err = LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"MODE": "auth"})))
+	var reqErr *RequiredFieldError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("LoadWith() error type = %T, want *RequiredFieldError", err)
+	}
+	if reqErr.FieldPath != "Server.Token" || reqErr.EnvName != "TOKEN" || reqErr.Condition != "when 'Server.Mode' is 'auth'" {
+		t.Errorf("RequiredFieldError = %+v", reqErr)
+	}
+
+	// Warunek odwołujący się do pola z błędem nie jest sprawdzany
+	var failed Config
+	err = LoadWith(&failed, WithLookuper(MapLookuper(map[string]string{"PORT": "abc"})))
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 1 {
+		t.Fatalf("LoadWith() error = %v, want only the parse error", err)
+	}
+	var parseErr *ParseError
+	if !errors.As(loadErrs[0], &parseErr) {
+		t.Errorf("error type = %T, want *ParseError", loadErrs[0])
+	}
+}
+
+// TestLoadStruct_ConditionDefaults sprawdza, że wartość domyślna nie sprawia, że pole jest
+// ustawione dla required_with i excluded_with, ale spełnia wymaganie pola
+func TestLoadStruct_ConditionDefaults(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Region   string `envconfig:"env=REGION,default=eu"`
+		Zone     string `envconfig:"env=ZONE,required_with=Region"`
+		Insecure bool   `envconfig:"env=INSECURE,default=false,excluded_with=Cert"`
+		Cert     string `envconfig:"env=CERT"`
+		Key      string `envconfig:"env=KEY,default=key.pem,required_with=Cert"`
+	}
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"CERT": "cert.pem"}))); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+
+	err := LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"REGION": "us"})))
+	var reqErr *RequiredFieldError
+	if !errors.As(err, &reqErr) || reqErr.FieldPath != "Zone" {
+		t.Errorf("LoadWith() error = %v, want RequiredFieldError for Zone", err)
+	}
+}
+
+// TestLoadStruct_InvalidConditions sprawdza, że niepoprawne warunki są błędami tagu
+func TestLoadStruct_InvalidConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  interface{}
+		wantMsg string
+	}{
+		{
+			name: "missing value",
+			config: &struct {
+				Mode  string
+				Field string `envconfig:"required_if=Mode"`
+			}{},
+			wantMsg: `invalid value "Mode" for key "required_if": expected FIELD:value`,
+		},
+		{
+			name: "unknown field",
+			config: &struct {
+				Field string `envconfig:"required_unless=MODE:s3"`
+			}{},
+			wantMsg: `key "required_unless" refers to unknown field "MODE"`,
+		},
+		{
+			name: "unexported field",
+			config: &struct {
+				mode  string
+				Field string `envconfig:"required_with=mode"`
+			}{},
+			wantMsg: `key "required_with" refers to unknown field "mode"`,
+		},
+		{
+			name: "nested struct",
+			config: &struct {
+				Database struct{ Host string }
+				Field    string `envconfig:"excluded_with=Database"`
+			}{},
+			wantMsg: `key "excluded_with" refers to nested struct "Database"`,
+		},
+		{
+			name: "invalid value",
+			config: &struct {
+				Port  int
+				Field string `envconfig:"required_if=Port:http"`
+			}{},
+			wantMsg: `invalid value "http" for key "required_if"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := LoadWith(tt.config, WithLookuper(MapLookuper(nil)))
+				var tagErr *TagSyntaxError
+				if !errors.As(err, &tagErr) {
+					t.Fatalf("LoadWith() error type = %T, want *TagSyntaxError", err)
+				}
+				if tagErr.FieldPath != "Field" || !strings.Contains(tagErr.Msg, tt.wantMsg) {
+					t.Errorf("TagSyntaxError = %+v, want message containing %q", tagErr, tt.wantMsg)
+				}
+			},
+		)
+	}
+}
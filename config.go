@@ -10,22 +10,72 @@ import (
 // Stałe używane do parsowania tagów struktury
 const (
 	Tag         = "envconfig" // Nazwa tagu używanego do konfiguracji
-	EnvKey      = "env"       // Klucz określający nazwę zmiennej środowiskowej
+	EnvKey      = "env"       // Klucz określający nazwę zmiennej środowiskowej (lub kilka nazw oddzielonych znakiem "|")
 	DefaultKey  = "default"   // Klucz określający wartość domyślną
 	RequiredKey = "required"  // Klucz określający czy pole jest wymagane
+
+	AllowEmptyKey = "allowEmpty" // Klucz określający czy pusta, ale ustawiona zmienna jest wartością
+	NotEmptyKey   = "notEmpty"   // Klucz określający czy ustawiona zmienna nie może być pusta
+
+	SeparatorKey         = "separator"   // Klucz określający separator elementów listy lub par mapy
+	KeyValueSeparatorKey = "kvSeparator" // Klucz określający separator klucza i wartości w parze mapy
+
+	PrefixKey = "prefix" // Klucz określający prefiks nazw zmiennych w zagnieżdżonej strukturze
+	NamingKey = "naming" // Klucz określający sposób wyprowadzania nazw zmiennych (upper lub snake)
+
+	ExpandKey = "expand" // Klucz określający czy w wartości rozwijane są odwołania ${VAR}
+	FileKey   = "file"   // Klucz określający czy wartość może być odczytana z pliku wskazanego przez zmienną z sufiksem _FILE
+	SecretKey = "secret" // Klucz określający czy wartość pola jest ukrywana w opisie konfiguracji
+
+	MinKey     = "min"     // Klucz określający minimalną wartość liczby lub czasu trwania
+	MaxKey     = "max"     // Klucz określający maksymalną wartość liczby lub czasu trwania
+	LenKey     = "len"     // Klucz określający dokładną długość tekstu, listy lub mapy
+	MinLenKey  = "minlen"  // Klucz określający minimalną długość tekstu, listy lub mapy
+	MaxLenKey  = "maxlen"  // Klucz określający maksymalną długość tekstu, listy lub mapy
+	OneOfKey   = "oneof"   // Klucz określający dozwolone wartości oddzielone znakiem "|"
+	PatternKey = "pattern" // Klucz określający wyrażenie regularne, do którego musi pasować cała wartość
+
+	RequiredIfKey     = "required_if"     // Klucz określający, że pole jest wymagane, gdy inne pole ma jedną z podanych wartości (POLE:wartość)
+	RequiredUnlessKey = "required_unless" // Klucz określający, że pole jest wymagane, chyba że inne pole ma jedną z podanych wartości (POLE:wartość)
+	RequiredWithKey   = "required_with"   // Klucz określający, że pole jest wymagane, gdy ustawione jest którekolwiek z podanych pól
+	ExcludedWithKey   = "excluded_with"   // Klucz określający, że pole nie może być ustawione, gdy ustawione jest którekolwiek z podanych pól
+
+	GroupKey      = "group"      // Klucz określający nazwę grupy pól sprawdzanej po załadowaniu struktury
+	ExclusiveKey  = "exclusive"  // Klucz określający czy w grupie musi być ustawione dokładnie jedno pole
+	AtLeastOneKey = "atleastone" // Klucz określający czy w grupie musi być ustawione co najmniej jedno pole
+
+	DeprecatedKey = "deprecated" // Klucz określający przestarzałe nazwy zmiennych oddzielone znakiem "|", które nadal są odczytywane
+)
+
+// Domyślne separatory używane przy parsowaniu kolekcji
+const (
+	DefaultSeparator         = "," // Separator elementów list (slice i tablic) oraz par map
+	DefaultKeyValueSeparator = ":" // Separator klucza i wartości w parze mapy
 )
 
+// FileSuffix jest doklejany do nazwy zmiennej, aby uzyskać nazwę zmiennej ze ścieżką pliku
+// zawierającego wartość, np. DB_PASSWORD_FILE dla DB_PASSWORD (zob. WithFileValues)
+const FileSuffix = "_FILE"
+
 // Load ładuje konfigurację z zmiennych środowiskowych do podanej struktury.
 // Parametr config musi być wskaźnikiem do struktury, w przeciwnym razie zostanie zwrócony błąd.
 // Funkcja przeszukuje wszystkie pola struktury i ustawia ich wartości na podstawie zmiennych środowiskowych
 // lub wartości domyślnych określonych w tagach struktury.
 // Jeśli pole jest oznaczone jako wymagane (required=true), a nie ma wartości, zwraca błąd.
 func Load(config interface{}) error {
+	return LoadWith(config)
+}
+
+// LoadWith działa jak Load, ale pozwala przekazać opcje modyfikujące sposób ładowania,
+// np. źródło wartości inne niż zmienne środowiskowe procesu:
+//
+//	err := envconfig.LoadWith(cfg, envconfig.WithLookuper(envconfig.MapLookuper(values)))
+func LoadWith(config interface{}, opts ...Option) error {
 	configValue := reflect.ValueOf(config)
 	// Sprawdzenie czy config jest wskaźnikiem do struktury
 	if configValue.Kind() != reflect.Ptr || configValue.Elem().Kind() != reflect.Struct {
 		return ErrNotStruct
 	}
 
-	return LoadStruct(configValue.Elem())
+	return LoadStruct(configValue.Elem(), opts...)
 }
@@ -218,3 +218,106 @@ func TestLoad_InvalidInput(t *testing.T) {
 	// Czyszczenie zmiennych środowiskowych
 	os.Unsetenv("TEST_INT")
 }
+
+// TestLoadWith_Lookuper sprawdza ładowanie konfiguracji z własnego źródła wartości
+func TestLoadWith_Lookuper(t *testing.T) {
+	t.Parallel()
+
+	type Nested struct {
+		Host string `envconfig:"env=LOOKUPER_HOST"`
+	}
+
+	type Config struct {
+		Port   int    `envconfig:"env=LOOKUPER_PORT,default=8080"`
+		Name   string `envconfig:"env=LOOKUPER_NAME,required=true"`
+		Nested Nested
+	}
+
+	source := MapLookuper(map[string]string{
+		"LOOKUPER_NAME": "app",
+		"LOOKUPER_HOST": "db.local",
+	})
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(source))
+	if err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %v, want %v", cfg.Port, 8080)
+	}
+	if cfg.Name != "app" {
+		t.Errorf("Name = %v, want %v", cfg.Name, "app")
+	}
+	if cfg.Nested.Host != "db.local" {
+		t.Errorf("Nested.Host = %v, want %v", cfg.Nested.Host, "db.local")
+	}
+
+	// Brak wymaganej wartości w źródle
+	err = LoadWith(&Config{}, WithLookuper(MapLookuper(nil)))
+	var reqErr *RequiredFieldError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("LoadWith() error type = %T, want *RequiredFieldError", err)
+	}
+	if reqErr.EnvName != "LOOKUPER_NAME" {
+		t.Errorf("RequiredFieldError.EnvName = %v, want %v", reqErr.EnvName, "LOOKUPER_NAME")
+	}
+}
+
+// TestLoadWith_Prefix sprawdza prefiksy nazw zmiennych na poziomie Load i zagnieżdżonych struktur
+func TestLoadWith_Prefix(t *testing.T) {
+	t.Parallel()
+
+	type DatabaseConfig struct {
+		Host string `envconfig:"env=DB_HOST,default=localhost"`
+		Port int    `envconfig:"env=DB_PORT,required=true"`
+	}
+
+	type ClusterConfig struct {
+		Primary DatabaseConfig  `envconfig:"prefix=PRIMARY_"`
+		Replica *DatabaseConfig `envconfig:"prefix=REPLICA_"`
+	}
+
+	type Config struct {
+		Name    string        `envconfig:"env=NAME"`
+		Cluster ClusterConfig `envconfig:"prefix=CLUSTER_"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"APP_NAME":                    "app",
+		"APP_CLUSTER_PRIMARY_DB_HOST": "primary.local",
+		"APP_CLUSTER_PRIMARY_DB_PORT": "5432",
+		"APP_CLUSTER_REPLICA_DB_PORT": "5433",
+		"CLUSTER_PRIMARY_DB_HOST":     "ignored",
+		"DB_HOST":                     "ignored",
+	})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(source), WithPrefix("APP_")); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+
+	if cfg.Name != "app" {
+		t.Errorf("Name = %v, want %v", cfg.Name, "app")
+	}
+	if cfg.Cluster.Primary.Host != "primary.local" || cfg.Cluster.Primary.Port != 5432 {
+		t.Errorf("Cluster.Primary = %+v, want primary.local:5432", cfg.Cluster.Primary)
+	}
+	if cfg.Cluster.Replica == nil {
+		t.Fatalf("Cluster.Replica = nil, want allocated struct")
+	}
+	if cfg.Cluster.Replica.Host != "localhost" || cfg.Cluster.Replica.Port != 5433 {
+		t.Errorf("Cluster.Replica = %+v, want localhost:5433", *cfg.Cluster.Replica)
+	}
+
+	// Nazwa zmiennej w błędzie zawiera pełny prefiks
+	err := LoadWith(&Config{}, WithLookuper(MapLookuper(nil)), WithPrefix("APP_"))
+	var reqErr *RequiredFieldError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("LoadWith() error type = %T, want *RequiredFieldError", err)
+	}
+	if reqErr.EnvName != "APP_CLUSTER_PRIMARY_DB_PORT" {
+		t.Errorf("RequiredFieldError.EnvName = %v, want %v", reqErr.EnvName, "APP_CLUSTER_PRIMARY_DB_PORT")
+	}
+}
@@ -0,0 +1,90 @@
+package envconfig
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// Decoder jest interfejsem dla typów, które samodzielnie parsują swoją wartość tekstową.
+// Typy implementujące Decoder (na wartości lub wskaźniku) mają pierwszeństwo
+// przed encoding.TextUnmarshaler, encoding.BinaryUnmarshaler i wbudowaną obsługą typów.
+type Decoder interface {
+	Decode(value string) error
+}
+
+// Typy interfejsów sprawdzanych przed wbudowaną obsługą typów
+var (
+	decoderType           = reflect.TypeOf((*Decoder)(nil)).Elem()
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// decodeValue ustawia wartość pola za pomocą Decoder, encoding.TextUnmarshaler
+// lub encoding.BinaryUnmarshaler, jeśli typ pola (lub wskaźnik do niego) implementuje
+// któryś z tych interfejsów. Zwraca false, jeśli pole nie obsługuje żadnego z nich.
+// Błędy dekodowania są opakowywane w ParseError.
+func decodeValue(field reflect.Value, value string, fieldName string) (bool, error) {
+	decode := decoderFunc(field)
+	if decode == nil {
+		return false, nil
+	}
+
+	if err := decode(value); err != nil {
+		return true, &ParseError{
+			FieldName: fieldName,
+			FieldType: field.Type().String(),
+			Value:     value,
+			Err:       err,
+		}
+	}
+	return true, nil
+}
+
+// decoderFunc zwraca funkcję dekodującą dla pola lub nil, jeśli pole nie implementuje
+// żadnego z obsługiwanych interfejsów. Najpierw sprawdzany jest wskaźnik do pola,
+// a następnie sama wartość.
+func decoderFunc(field reflect.Value) func(string) error {
+	// Metody wywołane na wskaźniku nil zakończyłyby się paniką - wskaźniki
+	// są alokowane wcześniej przez setValue
+	if field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		return nil
+	}
+
+	candidates := make([]reflect.Value, 0, 2)
+	if field.CanAddr() {
+		candidates = append(candidates, field.Addr())
+	}
+	candidates = append(candidates, field)
+
+	for _, candidate := range candidates {
+		if !candidate.CanInterface() {
+			continue
+		}
+		switch d := candidate.Interface().(type) {
+		case Decoder:
+			return d.Decode
+		case encoding.TextUnmarshaler:
+			return func(value string) error {
+				return d.UnmarshalText([]byte(value))
+			}
+		case encoding.BinaryUnmarshaler:
+			return func(value string) error {
+				return d.UnmarshalBinary([]byte(value))
+			}
+		}
+	}
+	return nil
+}
+
+// implementsDecoder sprawdza czy wartości typu t (lub wskaźniki do nich)
+// mogą być dekodowane przez decodeValue
+func implementsDecoder(t reflect.Type) bool {
+	for _, candidate := range []reflect.Type{t, reflect.PointerTo(t)} {
+		if candidate.Implements(decoderType) ||
+			candidate.Implements(textUnmarshalerType) ||
+			candidate.Implements(binaryUnmarshalerType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// logLevel implementuje encoding.TextUnmarshaler
+type logLevel int
+
+func (l *logLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	case "error":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown log level %q", text)
+	}
+	return nil
+}
+
+// region implementuje Decoder i encoding.TextUnmarshaler - Decoder ma pierwszeństwo
+type region struct {
+	Code   string
+	Source string
+}
+
+func (r *region) Decode(value string) error {
+	if len(value) != 2 {
+		return errors.New("region code must have 2 characters")
+	}
+	r.Code, r.Source = strings.ToUpper(value), "Decode"
+	return nil
+}
+
+func (r *region) UnmarshalText(text []byte) error {
+	r.Code, r.Source = string(text), "UnmarshalText"
+	return nil
+}
+
+// checksum implementuje encoding.BinaryUnmarshaler
+type checksum [4]byte
+
+func (c *checksum) UnmarshalBinary(data []byte) error {
+	if len(data) != len(c) {
+		return fmt.Errorf("checksum must have %d bytes", len(c))
+	}
+	copy(c[:], data)
+	return nil
+}
+
+// modes implementuje Decoder na wartości (a nie na wskaźniku)
+type modes map[string]bool
+
+func (m modes) Decode(value string) error {
+	for _, mode := range strings.Split(value, "+") {
+		m[mode] = true
+	}
+	return nil
+}
+
+// TestLoadStruct_Decoders sprawdza obsługę typów parsujących swoją wartość samodzielnie
+func TestLoadStruct_Decoders(t *testing.T) {
+	t.Parallel()
+
+	source := WithLookuper(MapLookuper(map[string]string{
+		"LOG_LEVEL":  "error",
+		"LOG_LEVELS": "debug,info",
+		"REGION":     "pl",
+		"CHECKSUM":   "abcd",
+		"IP":         "10.0.0.1",
+		"MODES":      "read+write",
+	}))
+
+	type Config struct {
+		Level    logLevel   `envconfig:"env=LOG_LEVEL"`
+		Levels   []logLevel `envconfig:"env=LOG_LEVELS"`
+		Default  *logLevel  `envconfig:"env=MISSING,default=info"`
+		Region   region     `envconfig:"env=REGION"`
+		Checksum checksum   `envconfig:"env=CHECKSUM"`
+		IP       net.IP     `envconfig:"env=IP"`
+		Modes    modes      `envconfig:"env=MODES"`
+	}
+
+	cfg := Config{Modes: modes{}}
+	if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.Level != 2 {
+		t.Errorf("Level = %v, want %v", cfg.Level, 2)
+	}
+	if !reflect.DeepEqual(cfg.Levels, []logLevel{0, 1}) {
+		t.Errorf("Levels = %v, want %v", cfg.Levels, []logLevel{0, 1})
+	}
+	if cfg.Default == nil || *cfg.Default != 1 {
+		t.Errorf("Default = %v, want pointer to %v", cfg.Default, 1)
+	}
+	if cfg.Region.Code != "PL" || cfg.Region.Source != "Decode" {
+		t.Errorf("Region = %+v, want code PL decoded by Decode", cfg.Region)
+	}
+	if string(cfg.Checksum[:]) != "abcd" {
+		t.Errorf("Checksum = %v, want %v", cfg.Checksum, "abcd")
+	}
+	if !cfg.IP.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("IP = %v, want %v", cfg.IP, "10.0.0.1")
+	}
+	if !cfg.Modes["read"] || !cfg.Modes["write"] {
+		t.Errorf("Modes = %v, want read and write", cfg.Modes)
+	}
+}
+
+// TestLoadStruct_DecoderErrors sprawdza opakowanie błędów dekodowania w ParseError
+func TestLoadStruct_DecoderErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		config    interface{}
+		value     string
+		wantField string
+		wantType  string
+	}{
+		{
+			name: "TextUnmarshaler",
+			config: &struct {
+				Level logLevel `envconfig:"env=VALUE"`
+			}{},
+			value:     "verbose",
+			wantField: "Level",
+			wantType:  "envconfig.logLevel",
+		},
+		{
+			name: "Decoder",
+			config: &struct {
+				Region region `envconfig:"env=VALUE"`
+			}{},
+			value:     "poland",
+			wantField: "Region",
+			wantType:  "envconfig.region",
+		},
+		{
+			name: "BinaryUnmarshaler",
+			config: &struct {
+				Checksum checksum `envconfig:"env=VALUE"`
+			}{},
+			value:     "abc",
+			wantField: "Checksum",
+			wantType:  "envconfig.checksum",
+		},
+		{
+			name: "Slice element",
+			config: &struct {
+				Levels []logLevel `envconfig:"env=VALUE"`
+			}{},
+			value:     "info,verbose",
+			wantField: "Levels[1]",
+			wantType:  "envconfig.logLevel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				source := WithLookuper(MapLookuper(map[string]string{"VALUE": tt.value}))
+				err := LoadStruct(reflect.ValueOf(tt.config).Elem(), source)
+
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("LoadStruct() error type = %T, want *ParseError", err)
+				}
+				if parseErr.FieldName != tt.wantField {
+					t.Errorf("ParseError.FieldName = %v, want %v", parseErr.FieldName, tt.wantField)
+				}
+				if parseErr.FieldType != tt.wantType {
+					t.Errorf("ParseError.FieldType = %v, want %v", parseErr.FieldType, tt.wantType)
+				}
+				if parseErr.Err == nil {
+					t.Errorf("ParseError.Err = nil, want decoder error")
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,243 @@
+package envconfig
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// RedactedValue zastępuje wartości pól oznaczonych jako sekret w opisie konfiguracji
+const RedactedValue = "[REDACTED]"
+
+// Format określa format wyjścia funkcji Dump
+type Format int
+
+// Formaty wyjścia funkcji Dump
+const (
+	FormatTable Format = iota // Tabela z kolumnami FIELD, ENV i VALUE
+	FormatJSON                // Tablica obiektów JSON z polami path, env, value i secret
+)
+
+// FieldDescription opisuje wartość pojedynczego pola załadowanej konfiguracji
+type FieldDescription struct {
+	Path    string `json:"path"`   // Kropkowana ścieżka pola, np. "Database.Host"
+	EnvName string `json:"env"`    // Nazwa zmiennej, z której ładowane jest pole
+	Value   string `json:"value"`  // Wartość pola w postaci tekstowej lub RedactedValue
+	Secret  bool   `json:"secret"` // Czy wartość pola jest ukryta
+}
+
+// Description opisuje wartości wszystkich pól konfiguracji w kolejności występowania w strukturze
+type Description []FieldDescription
+
+// Describe przechodzi przez załadowaną konfigurację tak samo jak LoadWith - z tymi samymi
+// nazwami zmiennych, prefiksami i regułami nazewnictwa wynikającymi z tagów i opcji -
+// i zwraca opis wartości wszystkich pól. Wartości pól typu Secret (SecretOf), pól oznaczonych
+// kluczem secret i wszystkich pól struktury oznaczonej tym kluczem są zastępowane przez RedactedValue;
+// pusta wartość sekretu pozostaje pusta, aby było widać, że nie została ustawiona.
+// Parametr config musi być wskaźnikiem do struktury.
+func Describe(config interface{}, opts ...Option) (Description, error) {
+	configValue := reflect.ValueOf(config)
+	if configValue.Kind() != reflect.Ptr || configValue.Elem().Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	l := &loader{opts: newOptions(opts)}
+	if l.opts.err != nil {
+		return nil, l.opts.err
+	}
+
+	var desc Description
+	l.describeStruct(configValue.Elem(), scope{prefix: l.opts.prefix, naming: l.opts.naming}, false, &desc)
+	if err := l.err(); err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// Dump zapisuje opis konfiguracji (zob. Describe) w podanym formacie
+func Dump(w io.Writer, config interface{}, format Format, opts ...Option) error {
+	desc, err := Describe(config, opts...)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatTable:
+		return desc.WriteTable(w)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(desc)
+	}
+	return fmt.Errorf("unknown dump format %d", format)
+}
+
+// WriteTable zapisuje opis jako tabelę z kolumnami FIELD, ENV i VALUE
+func (d Description) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tENV\tVALUE")
+	for _, field := range d {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", field.Path, field.EnvName, field.Value)
+	}
+	return tw.Flush()
+}
+
+// Attrs zwraca opis jako atrybuty slog - kluczem jest ścieżka pola, a wartością wartość pola
+func (d Description) Attrs() []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(d))
+	for _, field := range d {
+		attrs = append(attrs, slog.String(field.Path, field.Value))
+	}
+	return attrs
+}
+
+// LogValue implementuje interfejs slog.LogValuer, dzięki czemu opis można przekazać
+// bezpośrednio jako wartość atrybutu: logger.Info("config", "config", desc)
+func (d Description) LogValue() slog.Value {
+	return slog.GroupValue(d.Attrs()...)
+}
+
+// describeStruct dopisuje do opisu pola struktury, stosując te same reguły wyboru pól,
+// nazw zmiennych i prefiksów co loadStruct. Błędy tagów są zapisywane w loaderze.
+func (l *loader) describeStruct(structValue reflect.Value, sc scope, secret bool, desc *Description) {
+	structType := structValue.Type()
+	l.enter(structType)
+	defer l.leave(structType)
+
+	for i := 0; i < structValue.NumField(); i++ {
+		field := structValue.Field(i)
+		fieldType := structType.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		fieldPath := joinPath(sc.path, fieldType.Name)
+		tag := fieldType.Tag.Get(Tag)
+		tagMap, err := parseTag(tag)
+		if err != nil {
+			l.fail(tagError(err, fieldType.Name, fieldPath))
+			continue
+		}
+
+		naming := sc.naming
+		if value, ok := tagMap[NamingKey]; ok {
+			if naming, err = parseNaming(value); err != nil {
+				l.fail(tagError(newTagSyntaxError(tag, "%v", err), fieldType.Name, fieldPath))
+				continue
+			}
+		}
+
+		// Klucz secret na strukturze ukrywa wartości wszystkich jej pól
+		fieldSecret := secret || tagBool(tagMap, SecretKey, false) || isSecretType(field.Type())
+
+		if isNestedStruct(field.Type()) || isNestedStructPtr(field.Type()) {
+			if err := l.recursiveField(fieldType, fieldPath); err != nil {
+				l.fail(err)
+				continue
+			}
+			nested := scope{
+				path:   fieldPath,
+				prefix: sc.prefix + l.nestedPrefix(fieldType, tagMap, naming),
+				naming: naming,
+			}
+			// Dla wskaźnika równego nil opisujemy pola pustej struktury
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					field = reflect.New(field.Type().Elem())
+				}
+				field = field.Elem()
+			}
+			l.describeStruct(field, nested, fieldSecret, desc)
+			continue
+		}
+
+		value := formatValue(field, tagMap)
+		if fieldSecret {
+			value = redactedValue(field, value)
+		}
+		*desc = append(*desc, FieldDescription{
+			Path:    fieldPath,
+			EnvName: fieldEnvName(fieldType, tagMap, naming, sc.prefix),
+			Value:   value,
+			Secret:  fieldSecret,
+		})
+	}
+}
+
+// redactedValue zwraca RedactedValue w miejsce wartości sekretu, chyba że sekret
+// nie został ustawiony - wtedy zwraca pusty ciąg
+func redactedValue(field reflect.Value, value string) string {
+	if value == "" || field.IsZero() {
+		return ""
+	}
+	return RedactedValue
+}
+
+// formatValue zwraca tekstową postać wartości pola, zbliżoną do formatu, w jakim
+// wartość jest podawana w zmiennej środowiskowej. Kolekcje są łączone separatorami
+// z tagu, a wskaźnik równy nil jest opisywany pustym ciągiem.
+func formatValue(field reflect.Value, tagMap map[string]string) string {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		return formatValue(field.Elem(), tagMap)
+	}
+
+	// Typy potrafiące same się opisać (time.Time, time.Duration, net.IP itp.);
+	// tak jak przy dekodowaniu sprawdzamy najpierw wskaźnik, a potem samą wartość
+	candidates := []reflect.Value{field}
+	if field.CanAddr() {
+		candidates = []reflect.Value{field.Addr(), field}
+	}
+	for _, candidate := range candidates {
+		if !candidate.CanInterface() {
+			continue
+		}
+		switch v := candidate.Interface().(type) {
+		case encoding.TextMarshaler:
+			if text, err := v.MarshalText(); err == nil {
+				return string(text)
+			}
+		case fmt.Stringer:
+			return v.String()
+		}
+	}
+
+	separator, ok := tagMap[SeparatorKey]
+	if !ok || separator == "" {
+		separator = DefaultSeparator
+	}
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		if field.Type().Elem().Kind() == reflect.Uint8 && field.Kind() == reflect.Slice {
+			return string(field.Bytes())
+		}
+		items := make([]string, field.Len())
+		for i := range items {
+			items[i] = formatValue(field.Index(i), nil)
+		}
+		return strings.Join(items, separator)
+	case reflect.Map:
+		kvSeparator, ok := tagMap[KeyValueSeparatorKey]
+		if !ok || kvSeparator == "" {
+			kvSeparator = DefaultKeyValueSeparator
+		}
+		pairs := make([]string, 0, field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, formatValue(iter.Key(), nil)+kvSeparator+formatValue(iter.Value(), nil))
+		}
+		// Kolejność iteracji po mapie jest losowa, więc sortujemy pary dla stabilnego wyniku
+		sort.Strings(pairs)
+		return strings.Join(pairs, separator)
+	}
+	return fmt.Sprint(field.Interface())
+}
@@ -0,0 +1,183 @@
+package envconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// describeConfig jest konfiguracją używaną w testach opisu
+type describeConfig struct {
+	Name     string            `envconfig:"env=APP_NAME"`
+	Timeout  time.Duration     `envconfig:"env=TIMEOUT"`
+	Hosts    []string          `envconfig:"env=HOSTS,separator=;"`
+	Limits   map[string]int    `envconfig:"env=LIMITS"`
+	Password string            `envconfig:"env=PASSWORD,secret"`
+	APIKey   string            `envconfig:"env=API_KEY,secret"`
+	Headers  map[string]string `envconfig:"env=HEADERS"`
+	Database struct {
+		Host string
+		Port *int
+	} `envconfig:"prefix=DB_"`
+	Credentials *struct {
+		User  string `envconfig:"env=USER"`
+		Token string `envconfig:"env=TOKEN"`
+	} `envconfig:"prefix=CRED_,secret"`
+	internal string
+}
+
+// TestDescribe sprawdza opis wartości pól z ukrywaniem sekretów
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	var cfg describeConfig
+	source := MapLookuper(map[string]string{
+		"APP_NAME":   "demo",
+		"TIMEOUT":    "1m30s",
+		"HOSTS":      "a;b",
+		"LIMITS":     "b:2,a:1",
+		"PASSWORD":   "s3cr3t",
+		"DB_HOST":    "db.local",
+		"CRED_USER":  "admin",
+		"CRED_TOKEN": "t0k3n",
+	})
+	if err := LoadWith(&cfg, WithLookuper(source)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	cfg.internal = "hidden"
+
+	desc, err := Describe(&cfg)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+
+	want := Description{
+		{Path: "Name", EnvName: "APP_NAME", Value: "demo"},
+		{Path: "Timeout", EnvName: "TIMEOUT", Value: "1m30s"},
+		{Path: "Hosts", EnvName: "HOSTS", Value: "a;b"},
+		{Path: "Limits", EnvName: "LIMITS", Value: "a:1,b:2"},
+		{Path: "Password", EnvName: "PASSWORD", Value: RedactedValue, Secret: true},
+		{Path: "APIKey", EnvName: "API_KEY", Value: "", Secret: true},
+		{Path: "Headers", EnvName: "HEADERS", Value: ""},
+		{Path: "Database.Host", EnvName: "DB_HOST", Value: "db.local"},
+		{Path: "Database.Port", EnvName: "DB_PORT", Value: ""},
+		{Path: "Credentials.User", EnvName: "CRED_USER", Value: RedactedValue, Secret: true},
+		{Path: "Credentials.Token", EnvName: "CRED_TOKEN", Value: RedactedValue, Secret: true},
+	}
+	if !reflect.DeepEqual(desc, want) {
+		t.Errorf("Describe() =\n%+v\nwant\n%+v", desc, want)
+	}
+}
+
+// TestDescribe_Options sprawdza, że opis używa tych samych nazw zmiennych co ładowanie
+func TestDescribe_Options(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		MaxConns int
+		Database struct {
+			IdleTimeout time.Duration
+		}
+	}
+
+	var cfg Config
+	desc, err := Describe(&cfg, WithPrefix("APP_"), WithNaming(NamingSnake), WithNestedNames())
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if len(desc) != 2 || desc[0].EnvName != "APP_MAX_CONNS" || desc[1].EnvName != "APP_DATABASE_IDLE_TIMEOUT" {
+		t.Errorf("Describe() = %+v", desc)
+	}
+
+	if _, err := Describe(cfg); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Describe() error = %v, want ErrNotStruct", err)
+	}
+
+	type Invalid struct {
+		Field string `envconfig:"unknown=1"`
+	}
+	if _, err := Describe(&Invalid{}); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("Describe() error = %v, want ErrInvalidTag", err)
+	}
+
+	// Typ zawierający wskaźnik do samego siebie nie może zostać opisany
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	if _, err := Describe(&Node{}); !errors.Is(err, ErrUnsupportedFieldType) {
+		t.Errorf("Describe() error = %v, want ErrUnsupportedFieldType", err)
+	}
+}
+
+// TestDump sprawdza zapis opisu jako tabeli i JSON
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Host     string `envconfig:"env=HOST"`
+		Password string `envconfig:"env=DB_PASSWORD,secret"`
+	}
+	cfg := Config{Host: "localhost", Password: "s3cr3t"}
+
+	var table bytes.Buffer
+	if err := Dump(&table, &cfg, FormatTable); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	wantTable := "FIELD     ENV          VALUE\n" +
+		"Host      HOST         localhost\n" +
+		"Password  DB_PASSWORD  [REDACTED]\n"
+	if table.String() != wantTable {
+		t.Errorf("Dump(FormatTable) =\n%s\nwant\n%s", table.String(), wantTable)
+	}
+
+	var out bytes.Buffer
+	if err := Dump(&out, &cfg, FormatJSON); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	var decoded []FieldDescription
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(decoded) != 2 || decoded[1].Value != RedactedValue || !decoded[1].Secret {
+		t.Errorf("Dump(FormatJSON) = %s", out.String())
+	}
+	if strings.Contains(out.String(), "s3cr3t") {
+		t.Errorf("Dump(FormatJSON) leaked secret: %s", out.String())
+	}
+
+	if err := Dump(&out, &cfg, Format(42)); err == nil {
+		t.Error("Dump() with unknown format error = nil")
+	}
+}
+
+// TestDescription_LogValue sprawdza logowanie opisu przez slog
+func TestDescription_LogValue(t *testing.T) {
+	t.Parallel()
+
+	desc := Description{
+		{Path: "Host", EnvName: "HOST", Value: "localhost"},
+		{Path: "Password", EnvName: "DB_PASSWORD", Value: RedactedValue, Secret: true},
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("config loaded", "config", desc)
+
+	want := "level=INFO msg=\"config loaded\" config.Host=localhost config.Password=[REDACTED]\n"
+	if buf.String() != want {
+		t.Errorf("log output = %q, want %q", buf.String(), want)
+	}
+}
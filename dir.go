@@ -0,0 +1,81 @@
+package envconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirLookuper wczytuje katalog, w którym każdy plik odpowiada jednej zmiennej - nazwa pliku
+// jest kluczem, a jego zawartość (bez końcowego znaku nowej linii) wartością. Taki układ
+// mają zamontowane ConfigMapy i Secrety Kubernetesa, katalogi envdir z daemontools
+// oraz katalog $CREDENTIALS_DIRECTORY w usługach systemd.
+//
+// Pliki ukryte (o nazwach zaczynających się od ".") są pomijane, w tym katalogi "..data"
+// i "..2024_01_01_..." używane przez Kubernetesa do atomowej podmiany zawartości.
+// Dowiązania symboliczne są rozwiązywane, a podkatalogi i zerwane dowiązania (np. w trakcie
+// podmiany "..data") pomijane.
+// Katalog jest wczytywany jednorazowo, w momencie wywołania funkcji.
+func DirLookuper(dir string) (Lookuper, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		// Klucze w montowaniach Kubernetesa są dowiązaniami do plików w katalogu ..data,
+		// dlatego typ sprawdzamy po rozwiązaniu dowiązania
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Zerwane dowiązanie (np. w trakcie podmiany ..data lub nieaktualny wpis envdir)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Plik usunięty między odczytem katalogu a odczytem zawartości
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[name] = trimNewline(string(content))
+	}
+	return &dirLookuper{dir: dir, values: values}, nil
+}
+
+// dirLookuper odczytuje wartości wczytane z katalogu plików
+type dirLookuper struct {
+	dir    string
+	values map[string]string
+}
+
+// LookupEnv implementuje interfejs Lookuper
+func (d *dirLookuper) LookupEnv(key string) (string, bool) {
+	value, ok := d.values[key]
+	return value, ok
+}
+
+// LookupSource implementuje interfejs SourceLookuper - źródłem jest ścieżka pliku
+func (d *dirLookuper) LookupSource(key string) (string, string, bool) {
+	value, ok := d.values[key]
+	if !ok {
+		return "", "", false
+	}
+	return value, filepath.Join(d.dir, key), true
+}
@@ -0,0 +1,98 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDirLookuper sprawdza odczyt katalogu w układzie montowania Kubernetesa
+func TestDirLookuper(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mustWrite := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	mustSymlink := func(target, name string) {
+		t.Helper()
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Fatalf("Symlink() error = %v", err)
+		}
+	}
+
+	// Kubernetes: dane w katalogu z datą, "..data" wskazuje na niego, a klucze na pliki w "..data"
+	if err := os.Mkdir(filepath.Join(dir, "..2024_01_01_00_00_00.000"), 0o700); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+	mustWrite("..2024_01_01_00_00_00.000/DB_HOST", "db.local\n")
+	mustWrite("..2024_01_01_00_00_00.000/DB_PASSWORD", "s3cr3t")
+	mustSymlink("..2024_01_01_00_00_00.000", "..data")
+	mustSymlink("..data/DB_HOST", "DB_HOST")
+	mustSymlink("..data/DB_PASSWORD", "DB_PASSWORD")
+
+	// Zerwane dowiązanie, np. do klucza usuniętego w trakcie podmiany "..data"
+	mustSymlink("..data/STALE", "STALE")
+
+	// Zwykłe pliki, pliki ukryte i podkatalogi
+	mustWrite("CERT", "line1\nline2\n\n")
+	mustWrite("EMPTY", "")
+	mustWrite(".hidden", "ignored")
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o700); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+
+	l, err := DirLookuper(dir)
+	if err != nil {
+		t.Fatalf("DirLookuper() error = %v", err)
+	}
+
+	tests := []struct {
+		key       string
+		wantValue string
+		wantOk    bool
+	}{
+		{key: "DB_HOST", wantValue: "db.local", wantOk: true},
+		{key: "DB_PASSWORD", wantValue: "s3cr3t", wantOk: true},
+		{key: "CERT", wantValue: "line1\nline2\n", wantOk: true},
+		{key: "EMPTY", wantValue: "", wantOk: true},
+		{key: ".hidden", wantValue: "", wantOk: false},
+		{key: "..data", wantValue: "", wantOk: false},
+		{key: "nested", wantValue: "", wantOk: false},
+		{key: "STALE", wantValue: "", wantOk: false},
+		{key: "MISSING", wantValue: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		value, ok := l.LookupEnv(tt.key)
+		if value != tt.wantValue || ok != tt.wantOk {
+			t.Errorf("LookupEnv(%q) = (%q, %v), want (%q, %v)", tt.key, value, ok, tt.wantValue, tt.wantOk)
+		}
+	}
+
+	// Katalog można przekazać bezpośrednio jako źródło wartości
+	type Config struct {
+		Host     string `envconfig:"env=DB_HOST"`
+		Password string `envconfig:"env=DB_PASSWORD,required"`
+	}
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(l)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if cfg.Host != "db.local" || cfg.Password != "s3cr3t" {
+		t.Errorf("got %+v", cfg)
+	}
+}
+
+// TestDirLookuper_Errors sprawdza błędy odczytu katalogu
+func TestDirLookuper_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := DirLookuper(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("DirLookuper() error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,345 @@
+package envconfig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DotenvLookuper wczytuje podane pliki w formacie .env i zwraca Lookuper z ich wartościami.
+// Pliki są wczytywane po kolei, a wartości z późniejszych plików nadpisują wcześniejsze.
+// Odwołania ${VAR} są rozwijane na podstawie wartości zdefiniowanych wcześniej
+// (w tym samym lub poprzednim pliku), a następnie zmiennych środowiskowych procesu.
+// Format plików opisuje ParseDotenv.
+func DotenvLookuper(paths ...string) (Lookuper, error) {
+	dotenv, err := loadDotenv(OsLookuper(), paths...)
+	if err != nil {
+		return nil, err
+	}
+	return dotenv, nil
+}
+
+// loadDotenv wczytuje pliki .env tak jak DotenvLookuper, ale odwołania do zmiennych
+// niezdefiniowanych w plikach rozwiązuje w podanym źródle zamiast w środowisku procesu
+func loadDotenv(env Lookuper, paths ...string) (*dotenvLookuper, error) {
+	values := make(map[string]string)
+	files := make(map[string]string)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fallback := Chain(mapLookuper(values), env)
+		parsed, err := parseDotenv(content, path, fallback)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range parsed {
+			values[key] = value
+			files[key] = path
+		}
+	}
+	return &dotenvLookuper{values: values, files: files}, nil
+}
+
+// dotenvLookuper odczytuje wartości wczytane z plików .env i pamięta, z którego pliku
+// pochodzi każda z nich
+type dotenvLookuper struct {
+	values map[string]string
+	files  map[string]string // Ścieżka pliku, z którego pochodzi wartość klucza
+}
+
+// LookupEnv implementuje interfejs Lookuper
+func (d *dotenvLookuper) LookupEnv(key string) (string, bool) {
+	value, ok := d.values[key]
+	return value, ok
+}
+
+// LookupSource implementuje interfejs SourceLookuper - źródłem jest ścieżka pliku
+func (d *dotenvLookuper) LookupSource(key string) (string, string, bool) {
+	value, ok := d.values[key]
+	return value, d.files[key], ok
+}
+
+// lookupExpanded implementuje interfejs expandedLookuper - odwołania w wartościach
+// zostały rozwinięte podczas parsowania pliku, a wartości w apostrofach są dosłowne
+func (d *dotenvLookuper) lookupExpanded(key string) (string, bool, bool) {
+	value, ok := d.values[key]
+	return value, true, ok
+}
+
+// ParseDotenv parsuje zawartość w formacie .env i zwraca mapę zmiennych.
+// Nazwa jest używana wyłącznie w komunikatach błędów (zwykle jest to ścieżka pliku).
+//
+// Obsługiwany format:
+//   - puste linie i linie zaczynające się od "#" są pomijane
+//   - linia ma postać KLUCZ=WARTOŚĆ, opcjonalnie poprzedzoną słowem "export"
+//   - wartość bez cudzysłowów jest przycinana, a " #" rozpoczyna komentarz
+//   - wartość w apostrofach jest brana dosłownie
+//   - wartość w cudzysłowach może obejmować wiele linii i zawierać sekwencje
+//     \n, \r, \t, \", \\ oraz \$ (dosłowny znak "$")
+//   - w wartościach bez cudzysłowów i w cudzysłowach odwołania ${VAR} i $VAR są rozwijane
+//     na podstawie wcześniej zdefiniowanych zmiennych i zmiennych środowiskowych procesu
+//
+// Błędy składni są zwracane jako *DotenvSyntaxError z nazwą pliku i numerem linii.
+func ParseDotenv(r io.Reader, name string) (map[string]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseDotenv(content, name, OsLookuper())
+}
+
+// parseDotenv parsuje zawartość pliku .env; fallback służy do rozwijania odwołań
+// do zmiennych niezdefiniowanych wcześniej w tym pliku
+func parseDotenv(content []byte, file string, fallback Lookuper) (map[string]string, error) {
+	p := &dotenvParser{
+		file:     file,
+		lines:    strings.Split(string(bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))), "\n"),
+		values:   make(map[string]string),
+		fallback: fallback,
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.values, nil
+}
+
+// dotenvParser przechowuje stan parsowania pojedynczego pliku .env
+type dotenvParser struct {
+	file     string
+	lines    []string
+	line     int // Indeks bieżącej linii (od zera)
+	values   map[string]string
+	fallback Lookuper
+}
+
+// fail tworzy błąd składni dla bieżącej linii
+func (p *dotenvParser) fail(format string, args ...interface{}) error {
+	return &DotenvSyntaxError{
+		File: p.file,
+		Line: p.line + 1,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
+
+// parse przetwarza kolejne linie pliku
+func (p *dotenvParser) parse() error {
+	for ; p.line < len(p.lines); p.line++ {
+		line := strings.TrimSpace(p.lines[p.line])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Opcjonalny prefiks "export" znany z plików powłoki
+		if rest, ok := strings.CutPrefix(line, "export"); ok && rest != "" && isDotenvSpace(rest[0]) {
+			line = strings.TrimSpace(rest)
+		}
+
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			return p.fail("missing '=' in %q", line)
+		}
+		key = strings.TrimSpace(key)
+		if !isDotenvKey(key) {
+			return p.fail("invalid variable name %q", key)
+		}
+
+		// Komentarz oddzielony od znaku "=" białym znakiem (A= # opis) oznacza pustą wartość;
+		// sprawdzamy to przed usunięciem białych znaków, które rozpoczynają komentarz
+		raw := strings.TrimLeft(rest, " \t")
+		if raw != rest && strings.HasPrefix(raw, "#") {
+			p.values[key] = ""
+			continue
+		}
+
+		value, err := p.value(raw)
+		if err != nil {
+			return err
+		}
+		p.values[key] = value
+	}
+	return nil
+}
+
+// value parsuje wartość zmiennej rozpoczynającą się w bieżącej linii
+func (p *dotenvParser) value(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, "'"):
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", p.fail("unterminated single-quoted value")
+		}
+		if err := p.checkTrailing(raw[end+2:]); err != nil {
+			return "", err
+		}
+		return raw[1 : end+1], nil
+	case strings.HasPrefix(raw, `"`):
+		return p.doubleQuoted(raw[1:])
+	}
+
+	// Komentarz w wartości bez cudzysłowów musi być poprzedzony białym znakiem
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && isDotenvSpace(raw[i-1]) {
+			raw = raw[:i]
+			break
+		}
+	}
+	return p.expand(strings.TrimSpace(raw))
+}
+
+// doubleQuoted parsuje wartość w cudzysłowach, która może obejmować kolejne linie
+func (p *dotenvParser) doubleQuoted(raw string) (string, error) {
+	start := p.line
+	var b strings.Builder
+
+	for {
+		for i := 0; i < len(raw); i++ {
+			c := raw[i]
+			switch {
+			case c == '\\' && i+1 < len(raw):
+				i++
+				switch raw[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case '"', '\\':
+					b.WriteByte(raw[i])
+				case '$':
+					// Zabezpieczony znak "$" nie rozpoczyna odwołania
+					b.WriteByte('$')
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(raw[i])
+				}
+			case c == '"':
+				if err := p.checkTrailing(raw[i+1:]); err != nil {
+					return "", err
+				}
+				return b.String(), nil
+			case c == '$':
+				replacement, next, err := p.reference(raw, i)
+				if err != nil {
+					return "", err
+				}
+				b.WriteString(replacement)
+				i = next - 1
+			default:
+				b.WriteByte(c)
+			}
+		}
+
+		// Brak zamykającego cudzysłowu - wartość jest kontynuowana w następnej linii
+		if p.line+1 >= len(p.lines) {
+			p.line = start
+			return "", p.fail("unterminated double-quoted value")
+		}
+		p.line++
+		raw = p.lines[p.line]
+		b.WriteByte('\n')
+	}
+}
+
+// checkTrailing sprawdza, że po zamykającym cudzysłowie występuje tylko komentarz
+func (p *dotenvParser) checkTrailing(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return p.fail("unexpected characters after quoted value: %q", rest)
+	}
+	return nil
+}
+
+// expand rozwija odwołania ${VAR} i $VAR w wartości bez cudzysłowów
+func (p *dotenvParser) expand(value string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' {
+			b.WriteByte(value[i])
+			continue
+		}
+		replacement, next, err := p.reference(value, i)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(replacement)
+		i = next - 1
+	}
+	return b.String(), nil
+}
+
+// reference rozwija odwołanie rozpoczynające się znakiem "$" na pozycji i.
+// Zwraca wartość zmiennej oraz pozycję pierwszego znaku po odwołaniu.
+// Zmienne są szukane najpierw wśród wartości zdefiniowanych wcześniej w pliku,
+// a potem w źródle zapasowym; nieznane zmienne są zastępowane pustym ciągiem.
+// Znak "$", po którym nie występuje nazwa zmiennej, jest zachowywany.
+func (p *dotenvParser) reference(value string, i int) (string, int, error) {
+	rest := value[i+1:]
+	switch {
+	case strings.HasPrefix(rest, "{"):
+		end := strings.IndexByte(rest, '}')
+		if end < 0 {
+			return "", 0, p.fail("unterminated variable reference in %q", value)
+		}
+		name := rest[1:end]
+		if !isDotenvKey(name) {
+			return "", 0, p.fail("invalid variable reference ${%s}", name)
+		}
+		return p.lookup(name), i + 1 + end + 1, nil
+	case rest != "" && isDotenvKeyStart(rest[0]):
+		end := 1
+		for end < len(rest) && isDotenvKeyChar(rest[end]) {
+			end++
+		}
+		return p.lookup(rest[:end]), i + 1 + end, nil
+	}
+	return "$", i + 1, nil
+}
+
+// lookup zwraca wartość zmiennej zdefiniowanej wcześniej w pliku lub w źródle zapasowym
+func (p *dotenvParser) lookup(name string) string {
+	if value, ok := p.values[name]; ok {
+		return value
+	}
+	if p.fallback != nil {
+		value, _ := p.fallback.LookupEnv(name)
+		return value
+	}
+	return ""
+}
+
+// isDotenvKey sprawdza czy nazwa jest poprawną nazwą zmiennej
+func isDotenvKey(name string) bool {
+	if name == "" || !isDotenvKeyStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isDotenvKeyChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDotenvKeyStart sprawdza czy znak może rozpoczynać nazwę zmiennej
+func isDotenvKeyStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isDotenvKeyChar sprawdza czy znak może wystąpić w nazwie zmiennej
+func isDotenvKeyChar(c byte) bool {
+	return isDotenvKeyStart(c) || (c >= '0' && c <= '9') || c == '.'
+}
+
+// isDotenvSpace sprawdza czy znak jest białym znakiem w linii pliku .env
+func isDotenvSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
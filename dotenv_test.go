@@ -0,0 +1,273 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeDotenv zapisuje plik .env w katalogu tymczasowym testu i zwraca jego ścieżkę
+func writeDotenv(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+// TestParseDotenv sprawdza obsługę składni plików .env
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("TEST_DOTENV_HOME", "/home/test")
+
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{
+			name:    "comments and blank lines",
+			content: "# komentarz\n\nHOST=localhost\n   # wcięty komentarz\nPORT=8080\n",
+			want:    map[string]string{"HOST": "localhost", "PORT": "8080"},
+		},
+		{
+			name:    "export prefix",
+			content: "export HOST=localhost\nexport\tPORT = 8080\nexported=1",
+			want:    map[string]string{"HOST": "localhost", "PORT": "8080", "exported": "1"},
+		},
+		{
+			name:    "unquoted value",
+			content: "URL = http://host/?a=b#frag  # komentarz\nEMPTY=\nSPACES=  a b  ",
+			want:    map[string]string{"URL": "http://host/?a=b#frag", "EMPTY": "", "SPACES": "a b"},
+		},
+		{
+			name:    "comment instead of value",
+			content: "A= # komentarz\nB=\t# komentarz\nC=#hash",
+			want:    map[string]string{"A": "", "B": "", "C": "#hash"},
+		},
+		{
+			name:    "single quotes",
+			content: `PASSWORD='p@ss # $HOME \n'  # komentarz`,
+			want:    map[string]string{"PASSWORD": `p@ss # $HOME \n`},
+		},
+		{
+			name:    "double quotes with escapes",
+			content: `MSG="a\tb\nc \"d\" \\ \$HOME \x"`,
+			want:    map[string]string{"MSG": "a\tb\nc \"d\" \\ $HOME \\x"},
+		},
+		{
+			name:    "multiline double quotes",
+			content: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1",
+			want:    map[string]string{"KEY": "-----BEGIN-----\nabc\n-----END-----", "NEXT": "1"},
+		},
+		{
+			name:    "references",
+			content: "HOST=db\nURL=postgres://${HOST}:5432\nQUOTED=\"$HOST/x\"\nHOME_DIR=${TEST_DOTENV_HOME}\nMISSING=[${TEST_DOTENV_MISSING}]\nLITERAL=$ 5",
+			want: map[string]string{
+				"HOST":     "db",
+				"URL":      "postgres://db:5432",
+				"QUOTED":   "db/x",
+				"HOME_DIR": "/home/test",
+				"MISSING":  "[]",
+				"LITERAL":  "$ 5",
+			},
+		},
+		{
+			name:    "windows line endings",
+			content: "HOST=localhost\r\nPORT=8080\r\n",
+			want:    map[string]string{"HOST": "localhost", "PORT": "8080"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, err := ParseDotenv(strings.NewReader(tt.content), ".env")
+				if err != nil {
+					t.Fatalf("ParseDotenv() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ParseDotenv() = %q, want %q", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+// TestParseDotenv_Errors sprawdza, że błędy składni wskazują plik i numer linii
+func TestParseDotenv_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		wantLine int
+		wantMsg  string
+	}{
+		{name: "missing equals", content: "HOST=a\nPORT\n", wantLine: 2, wantMsg: "missing '='"},
+		{name: "invalid name", content: "1HOST=a", wantLine: 1, wantMsg: "invalid variable name"},
+		{name: "unterminated single", content: "\nA='abc", wantLine: 2, wantMsg: "unterminated single-quoted value"},
+		{name: "unterminated double", content: "A=1\nB=\"abc\n\ndef", wantLine: 2, wantMsg: "unterminated double-quoted value"},
+		{name: "trailing characters", content: `A="abc" def`, wantLine: 1, wantMsg: "unexpected characters"},
+		{name: "unterminated reference", content: "A=${HOST", wantLine: 1, wantMsg: "unterminated variable reference"},
+		{name: "invalid reference", content: "A=${1X}", wantLine: 1, wantMsg: "invalid variable reference"},
+		{name: "line after multiline", content: "A=\"x\ny\"\nB", wantLine: 3, wantMsg: "missing '='"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := ParseDotenv(strings.NewReader(tt.content), "app.env")
+				var syntaxErr *DotenvSyntaxError
+				if !errors.As(err, &syntaxErr) {
+					t.Fatalf("ParseDotenv() error type = %T, want *DotenvSyntaxError", err)
+				}
+				if syntaxErr.File != "app.env" || syntaxErr.Line != tt.wantLine {
+					t.Errorf("error position = %s:%d, want app.env:%d", syntaxErr.File, syntaxErr.Line, tt.wantLine)
+				}
+				if !strings.Contains(syntaxErr.Msg, tt.wantMsg) {
+					t.Errorf("error message = %q, want it to contain %q", syntaxErr.Msg, tt.wantMsg)
+				}
+				if !errors.Is(err, ErrInvalidDotenv) {
+					t.Error("error does not match ErrInvalidDotenv")
+				}
+			},
+		)
+	}
+}
+
+// TestDotenvLookuper sprawdza wczytywanie kilku plików, gdzie późniejsze nadpisują wcześniejsze
+func TestDotenvLookuper(t *testing.T) {
+	t.Parallel()
+
+	base := writeDotenv(t, ".env", "HOST=localhost\nPORT=8080\n")
+	local := writeDotenv(t, ".env.local", "PORT=9090\nURL=http://${HOST}:${PORT}\n")
+
+	l, err := DotenvLookuper(base, local)
+	if err != nil {
+		t.Fatalf("DotenvLookuper() error = %v", err)
+	}
+
+	tests := []struct {
+		key       string
+		wantValue string
+		wantOk    bool
+	}{
+		{key: "HOST", wantValue: "localhost", wantOk: true},
+		{key: "PORT", wantValue: "9090", wantOk: true},
+		{key: "URL", wantValue: "http://localhost:9090", wantOk: true},
+		{key: "MISSING", wantValue: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		value, ok := l.LookupEnv(tt.key)
+		if value != tt.wantValue || ok != tt.wantOk {
+			t.Errorf("LookupEnv(%q) = (%v, %v), want (%v, %v)", tt.key, value, ok, tt.wantValue, tt.wantOk)
+		}
+	}
+
+	if _, err := DotenvLookuper(filepath.Join(t.TempDir(), "missing.env")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("DotenvLookuper() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+// TestLoadWith_Dotenv sprawdza nakładanie plików .env pod i nad źródłem wartości
+func TestLoadWith_Dotenv(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Host  string `envconfig:"env=HOST"`
+		Port  int    `envconfig:"env=PORT"`
+		Debug bool   `envconfig:"env=DEBUG,default=false"`
+	}
+
+	path := writeDotenv(t, ".env", "HOST=file-host\nPORT=5432\nDEBUG=true\n")
+	env := MapLookuper(map[string]string{"HOST": "env-host"})
+
+	var under Config
+	if err := LoadWith(&under, WithDotenv(path), WithLookuper(env)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if want := (Config{Host: "env-host", Port: 5432, Debug: true}); under != want {
+		t.Errorf("WithDotenv: got %+v, want %+v", under, want)
+	}
+
+	var over Config
+	if err := LoadWith(&over, WithLookuper(env), WithDotenvOverride(path)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if want := (Config{Host: "file-host", Port: 5432, Debug: true}); over != want {
+		t.Errorf("WithDotenvOverride: got %+v, want %+v", over, want)
+	}
+
+	invalid := writeDotenv(t, "broken.env", "HOST=a\n\nPORT\n")
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(env), WithDotenv(invalid))
+	var syntaxErr *DotenvSyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("LoadWith() error type = %T, want *DotenvSyntaxError", err)
+	}
+	if syntaxErr.File != invalid || syntaxErr.Line != 3 {
+		t.Errorf("error position = %s:%d, want %s:3", syntaxErr.File, syntaxErr.Line, invalid)
+	}
+}
+
+// TestLoadWith_DotenvReferences sprawdza, że odwołania w plikach .env są rozwiązywane
+// w źródle ustawionym przez WithLookuper, a nie w środowisku procesu
+func TestLoadWith_DotenvReferences(t *testing.T) {
+	t.Setenv("ENVCONF_DOTENV_PROBE", "from-process")
+
+	type Config struct {
+		Host string `envconfig:"env=HOST"`
+		Port int    `envconfig:"env=PORT"`
+	}
+
+	path := writeDotenv(t, ".env", "HOST=${ENVCONF_DOTENV_PROBE}\nPORT=$PROBE_PORT\n")
+	env := MapLookuper(map[string]string{"ENVCONF_DOTENV_PROBE": "from-map", "PROBE_PORT": "6543"})
+
+	for _, opt := range []Option{WithDotenv(path), WithDotenvOverride(path)} {
+		var cfg Config
+		if err := LoadWith(&cfg, WithLookuper(env), opt); err != nil {
+			t.Fatalf("LoadWith() error = %v", err)
+		}
+		if want := (Config{Host: "from-map", Port: 6543}); cfg != want {
+			t.Errorf("got %+v, want %+v", cfg, want)
+		}
+	}
+}
+
+// TestLoadWith_DotenvExpand sprawdza, że WithExpand nie rozwija ponownie wartości z plików .env
+func TestLoadWith_DotenvExpand(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Literal string `envconfig:"env=LITERAL"`
+		Escaped string `envconfig:"env=ESCAPED"`
+		Ref     string `envconfig:"env=REF"`
+		FromEnv string `envconfig:"env=FROM_ENV"`
+		Default string `envconfig:"env=DEFAULT,default=${LITERAL}"`
+	}
+
+	path := writeDotenv(t, ".env", "LITERAL='${HOME}'\nESCAPED=\"\\${X}\"\nREF=${X}\n")
+	env := MapLookuper(map[string]string{"HOME": "/home/app", "X": "x", "FROM_ENV": "${LITERAL}/${X}"})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(env), WithDotenv(path), WithExpand()); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	want := Config{
+		Literal: "${HOME}",
+		Escaped: "${X}",
+		Ref:     "x",
+		FromEnv: "${HOME}/x",
+		Default: "${HOME}",
+	}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+}
@@ -5,6 +5,7 @@ package envconfig
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // Podstawowe błędy zwracane przez pakiet
@@ -17,25 +18,133 @@ var (
 
 	// ErrMissingRequired zwracany gdy wymagane pole nie ma wartości
 	ErrMissingRequired = errors.New("missing required field")
+
+	// ErrEmptyValue zwracany gdy zmienna jest ustawiona, ale pusta, a pole tego zabrania
+	ErrEmptyValue = errors.New("empty value")
+
+	// ErrInvalidTag zwracany gdy tag struktury ma niepoprawną składnię
+	ErrInvalidTag = errors.New("invalid struct tag")
+
+	// ErrInvalidDotenv zwracany gdy plik .env ma niepoprawną składnię
+	ErrInvalidDotenv = errors.New("invalid dotenv file")
+
+	// ErrReferenceCycle zwracany gdy odwołania ${VAR} tworzą cykl
+	ErrReferenceCycle = errors.New("cyclic variable reference")
+
+	// ErrUnsetReference zwracany gdy zmienna z odwołania ${VAR:?komunikat} nie ma wartości
+	ErrUnsetReference = errors.New("required variable reference is not set")
+
+	// ErrConflict zwracany gdy wartość pola jest ustawiona jednocześnie w kilku wykluczających się zmiennych
+	ErrConflict = errors.New("conflicting values")
+
+	// ErrValidation zwracany gdy wartość pola nie spełnia reguły walidacji z tagu
+	ErrValidation = errors.New("validation failed")
+
+	// ErrExcludedField zwracany gdy pole jest ustawione, choć wyklucza je inne ustawione pole
+	ErrExcludedField = errors.New("excluded field")
+
+	// ErrFieldGroup zwracany gdy liczba ustawionych zmiennych pól grupy narusza jej regułę
+	ErrFieldGroup = errors.New("invalid field group")
 )
 
 // RequiredFieldError reprezentuje błąd brakującego wymaganego pola
 type RequiredFieldError struct {
 	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Database.Primary.Host"
 	EnvName   string
+	Condition string // Warunek wymagania z kluczy required_if, required_unless i required_with, np. "when 'Storage' is 's3'"
 }
 
 // Error implementuje interfejs error
 func (e *RequiredFieldError) Error() string {
+	if e.Condition != "" {
+		return fmt.Sprintf(
+			"%s: field '%s' is required %s but no value was provided (env: %s)",
+			ErrMissingRequired.Error(), fieldDisplayName(e.FieldPath, e.FieldName), e.Condition, e.EnvName,
+		)
+	}
 	return fmt.Sprintf(
 		"%s: field '%s' is required but no value was provided (env: %s)",
-		ErrMissingRequired.Error(), e.FieldName, e.EnvName,
+		ErrMissingRequired.Error(), fieldDisplayName(e.FieldPath, e.FieldName), e.EnvName,
+	)
+}
+
+// ExcludedFieldError reprezentuje błąd pola ustawionego mimo warunku excluded_with
+type ExcludedFieldError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Server.TLS.Insecure"
+	EnvName   string
+	Condition string // Warunek wykluczenia, np. "when 'Cert' is set"
+}
+
+// Error implementuje interfejs error
+func (e *ExcludedFieldError) Error() string {
+	return fmt.Sprintf(
+		"%s: field '%s' must not be set %s (env: %s)",
+		ErrExcludedField.Error(), fieldDisplayName(e.FieldPath, e.FieldName), e.Condition, e.EnvName,
+	)
+}
+
+// Unwrap pozwala dopasować błąd do ErrExcludedField za pomocą errors.Is
+func (e *ExcludedFieldError) Unwrap() error {
+	return ErrExcludedField
+}
+
+// GroupError reprezentuje naruszenie reguły grupy pól (klucze group, exclusive i atleastone)
+type GroupError struct {
+	Group       string   // Nazwa grupy z tagu
+	StructPath  string   // Kropkowana ścieżka struktury zawierającej grupę; pusta dla struktury głównej
+	Rule        string   // Reguła grupy: "exactly one" lub "at least one"
+	EnvNames    []string // Nazwy zmiennych wszystkich pól grupy
+	SetEnvNames []string // Nazwy ustawionych zmiennych pól grupy
+}
+
+// Error implementuje interfejs error
+func (e *GroupError) Error() string {
+	group := fmt.Sprintf("group '%s'", e.Group)
+	if e.StructPath != "" {
+		group += fmt.Sprintf(" in '%s'", e.StructPath)
+	}
+	set := "none"
+	if len(e.SetEnvNames) > 0 {
+		set = strings.Join(e.SetEnvNames, ", ")
+	}
+	return fmt.Sprintf(
+		"%s: %s requires %s of %s to be set (set: %s)",
+		ErrFieldGroup.Error(), group, e.Rule, strings.Join(e.EnvNames, ", "), set,
+	)
+}
+
+// Unwrap pozwala dopasować błąd do ErrFieldGroup za pomocą errors.Is
+func (e *GroupError) Unwrap() error {
+	return ErrFieldGroup
+}
+
+// EmptyValueError reprezentuje błąd zmiennej ustawionej na pusty ciąg dla pola oznaczonego notEmpty
+type EmptyValueError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Database.Primary.Host"
+	EnvName   string
+}
+
+// Error implementuje interfejs error
+func (e *EmptyValueError) Error() string {
+	return fmt.Sprintf(
+		"%s: field '%s' must not be empty but an empty value was provided (env: %s)",
+		ErrEmptyValue.Error(), fieldDisplayName(e.FieldPath, e.FieldName), e.EnvName,
 	)
 }
 
+// Unwrap pozwala dopasować błąd do ErrEmptyValue za pomocą errors.Is
+func (e *EmptyValueError) Unwrap() error {
+	return ErrEmptyValue
+}
+
 // ParseError reprezentuje błąd podczas parsowania wartości
 type ParseError struct {
 	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Server.Ports[1]"
+	EnvName   string
 	FieldType string
 	Value     string
 	Err       error
@@ -43,13 +152,233 @@ type ParseError struct {
 
 // Error implementuje interfejs error
 func (e *ParseError) Error() string {
-	return fmt.Sprintf(
-		"failed to parse value '%s' as %s for field '%s': %v",
-		e.Value, e.FieldType, e.FieldName, e.Err,
+	msg := fmt.Sprintf(
+		"failed to parse value '%s' as %s for field '%s'",
+		e.Value, e.FieldType, fieldDisplayName(e.FieldPath, e.FieldName),
 	)
+	if e.EnvName != "" {
+		msg += fmt.Sprintf(" (env: %s)", e.EnvName)
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
 }
 
 // Unwrap implementuje interfejs errors.Unwrap
 func (e *ParseError) Unwrap() error {
 	return e.Err
 }
+
+// UnsupportedFieldTypeError reprezentuje błąd pola o typie, którego biblioteka nie obsługuje
+type UnsupportedFieldTypeError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Server.Handlers[0]"
+	EnvName   string
+	FieldType string // Typ Go pola, np. "chan int"
+}
+
+// Error implementuje interfejs error
+func (e *UnsupportedFieldTypeError) Error() string {
+	msg := fmt.Sprintf(
+		"%s: field '%s' has type %s",
+		ErrUnsupportedFieldType.Error(), fieldDisplayName(e.FieldPath, e.FieldName), e.FieldType,
+	)
+	if e.EnvName != "" {
+		msg += fmt.Sprintf(" (env: %s)", e.EnvName)
+	}
+	return msg
+}
+
+// Unwrap pozwala dopasować błąd do ErrUnsupportedFieldType za pomocą errors.Is
+func (e *UnsupportedFieldTypeError) Unwrap() error {
+	return ErrUnsupportedFieldType
+}
+
+// TagSyntaxError reprezentuje błąd składni tagu envconfig na polu struktury
+type TagSyntaxError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Database.Host"
+	Tag       string // Pełna treść tagu
+	Msg       string // Opis problemu
+}
+
+// newTagSyntaxError tworzy błąd składni tagu; pola struktury są uzupełniane przez loader
+func newTagSyntaxError(tag string, format string, args ...interface{}) *TagSyntaxError {
+	return &TagSyntaxError{
+		Tag: tag,
+		Msg: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implementuje interfejs error
+func (e *TagSyntaxError) Error() string {
+	return fmt.Sprintf(
+		"%s: field '%s': %s (tag: `%s`)",
+		ErrInvalidTag.Error(), fieldDisplayName(e.FieldPath, e.FieldName), e.Msg, e.Tag,
+	)
+}
+
+// Unwrap pozwala dopasować błąd do ErrInvalidTag za pomocą errors.Is
+func (e *TagSyntaxError) Unwrap() error {
+	return ErrInvalidTag
+}
+
+// DotenvSyntaxError reprezentuje błąd składni w pliku .env
+type DotenvSyntaxError struct {
+	File string // Nazwa (ścieżka) pliku
+	Line int    // Numer linii, licząc od 1
+	Msg  string // Opis problemu
+}
+
+// Error implementuje interfejs error
+func (e *DotenvSyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s:%d: %s", ErrInvalidDotenv.Error(), e.File, e.Line, e.Msg)
+}
+
+// Unwrap pozwala dopasować błąd do ErrInvalidDotenv za pomocą errors.Is
+func (e *DotenvSyntaxError) Unwrap() error {
+	return ErrInvalidDotenv
+}
+
+// InterpolationError reprezentuje błąd rozwijania odwołań ${VAR} w wartości pola
+type InterpolationError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Database.URL"
+	EnvName   string
+	Chain     []string // Łańcuch odwołań prowadzący do błędu, np. ["DB_URL", "DB_HOST", "DB_URL"]
+	Err       error
+}
+
+// Error implementuje interfejs error
+func (e *InterpolationError) Error() string {
+	msg := fmt.Sprintf("failed to expand value for field '%s'", fieldDisplayName(e.FieldPath, e.FieldName))
+	if e.EnvName != "" {
+		msg += fmt.Sprintf(" (env: %s)", e.EnvName)
+	}
+	msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	if len(e.Chain) > 0 {
+		msg += fmt.Sprintf(" (reference chain: %s)", strings.Join(e.Chain, " -> "))
+	}
+	return msg
+}
+
+// Unwrap implementuje interfejs errors.Unwrap
+func (e *InterpolationError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError reprezentuje błąd pola, którego wartość jest ustawiona w kilku zmiennych
+// jednocześnie, np. DB_PASSWORD i DB_PASSWORD_FILE
+type ConflictError struct {
+	FieldName string
+	FieldPath string   // Kropkowana ścieżka pola, np. "Database.Password"
+	EnvNames  []string // Nazwy ustawionych zmiennych, z których może pochodzić wartość pola
+}
+
+// Error implementuje interfejs error
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf(
+		"%s: field '%s' is set by more than one variable: %s",
+		ErrConflict.Error(), fieldDisplayName(e.FieldPath, e.FieldName), strings.Join(e.EnvNames, ", "),
+	)
+}
+
+// Unwrap pozwala dopasować błąd do ErrConflict za pomocą errors.Is
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// FileError reprezentuje błąd odczytu pliku z wartością pola
+type FileError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Database.Password"
+	EnvName   string // Nazwa zmiennej wskazującej plik, np. "DB_PASSWORD_FILE"
+	Path      string // Ścieżka pliku
+	Err       error
+}
+
+// Error implementuje interfejs error
+func (e *FileError) Error() string {
+	return fmt.Sprintf(
+		"failed to read file '%s' for field '%s' (env: %s): %v",
+		e.Path, fieldDisplayName(e.FieldPath, e.FieldName), e.EnvName, e.Err,
+	)
+}
+
+// Unwrap implementuje interfejs errors.Unwrap
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError reprezentuje wartość pola, która nie spełnia reguły walidacji z tagu
+type ValidationError struct {
+	FieldName string
+	FieldPath string // Kropkowana ścieżka pola, np. "Server.Port" lub "Levels[1]"
+	EnvName   string
+	Rule      string // Niespełniona reguła w postaci z tagu, np. "max=65535"
+	Value     string // Wartość pola (dla sekretów RedactedValue)
+}
+
+// Error implementuje interfejs error
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("%s for field '%s'", ErrValidation.Error(), fieldDisplayName(e.FieldPath, e.FieldName))
+	if e.EnvName != "" {
+		msg += fmt.Sprintf(" (env: %s)", e.EnvName)
+	}
+	return fmt.Sprintf("%s: value '%s' does not satisfy %s", msg, e.Value, e.Rule)
+}
+
+// Unwrap pozwala dopasować błąd do ErrValidation za pomocą errors.Is
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// StructValidationError opakowuje błąd zwrócony przez metodę Validate struktury konfiguracyjnej
+type StructValidationError struct {
+	FieldPath string // Kropkowana ścieżka struktury, np. "Server.TLS"; pusta dla struktury głównej
+	Err       error
+}
+
+// Error implementuje interfejs error
+func (e *StructValidationError) Error() string {
+	if e.FieldPath == "" {
+		return fmt.Sprintf("%s: %v", ErrValidation.Error(), e.Err)
+	}
+	return fmt.Sprintf("%s for struct '%s': %v", ErrValidation.Error(), e.FieldPath, e.Err)
+}
+
+// Unwrap pozwala dopasować błąd do ErrValidation oraz do błędu zwróconego przez Validate
+func (e *StructValidationError) Unwrap() []error {
+	return []error{ErrValidation, e.Err}
+}
+
+// fieldDisplayName zwraca pełną ścieżkę pola, a jeśli nie jest znana - samą nazwę pola
+func fieldDisplayName(fieldPath string, fieldName string) string {
+	if fieldPath != "" {
+		return fieldPath
+	}
+	return fieldName
+}
+
+// LoadErrors zbiera wszystkie błędy pól napotkane podczas ładowania konfiguracji.
+// Implementuje Unwrap() []error, dzięki czemu errors.Is i errors.As znajdują
+// pojedyncze błędy, takie jak RequiredFieldError czy ParseError.
+type LoadErrors []error
+
+// Error implementuje interfejs error, zwracając czytelne podsumowanie wszystkich błędów
+func (e LoadErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors occurred while loading configuration:", len(e))
+	for _, err := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap zwraca wszystkie zebrane błędy
+func (e LoadErrors) Unwrap() []error {
+	return e
+}
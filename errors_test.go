@@ -2,6 +2,8 @@ package envconfig
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"testing"
 )
 
@@ -17,6 +19,21 @@ func TestRequiredFieldError_Error(t *testing.T) {
 	}
 }
 
+func TestEmptyValueError_Error(t *testing.T) {
+	err := &EmptyValueError{
+		FieldName: "Token",
+		EnvName:   "API_TOKEN",
+	}
+
+	expected := "empty value: field 'Token' must not be empty but an empty value was provided (env: API_TOKEN)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrEmptyValue) {
+		t.Error("EmptyValueError does not match ErrEmptyValue")
+	}
+}
+
 func TestParseError_Error(t *testing.T) {
 	innerErr := errors.New("invalid syntax")
 	err := &ParseError{
@@ -41,6 +58,23 @@ func TestParseError_Unwrap(t *testing.T) {
 	}
 }
 
+func TestUnsupportedFieldTypeError_Error(t *testing.T) {
+	err := &UnsupportedFieldTypeError{
+		FieldName: "Handlers[0]",
+		FieldPath: "Server.Handlers[0]",
+		EnvName:   "SERVER_HANDLERS",
+		FieldType: "func()",
+	}
+
+	expected := "unsupported field type: field 'Server.Handlers[0]' has type func() (env: SERVER_HANDLERS)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrUnsupportedFieldType) {
+		t.Error("UnsupportedFieldTypeError does not match ErrUnsupportedFieldType")
+	}
+}
+
 func TestErrorsConstants(t *testing.T) {
 	if ErrNotStruct.Error() != "envconfig must be a pointer to a struct" {
 		t.Error("ErrNotStruct does not match expected message")
@@ -52,3 +86,244 @@ func TestErrorsConstants(t *testing.T) {
 		t.Error("ErrMissingRequired does not match expected message")
 	}
 }
+
+func TestLoadErrors_Error(t *testing.T) {
+	single := LoadErrors{&RequiredFieldError{FieldName: "Host", EnvName: "HOST"}}
+	expected := "missing required field: field 'Host' is required but no value was provided (env: HOST)"
+	if single.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, single.Error())
+	}
+
+	multiple := LoadErrors{
+		&RequiredFieldError{FieldName: "Host", EnvName: "HOST"},
+		&ParseError{FieldName: "Port", FieldType: "int", Value: "abc", Err: errors.New("invalid syntax")},
+	}
+	expected = "2 errors occurred while loading configuration:\n" +
+		"  - missing required field: field 'Host' is required but no value was provided (env: HOST)\n" +
+		"  - failed to parse value 'abc' as int for field 'Port': invalid syntax"
+	if multiple.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, multiple.Error())
+	}
+}
+
+func TestLoadErrors_Unwrap(t *testing.T) {
+	innerErr := errors.New("invalid syntax")
+	err := error(LoadErrors{
+		&RequiredFieldError{FieldName: "Host", EnvName: "HOST"},
+		&ParseError{FieldName: "Port", Err: innerErr},
+	})
+
+	var reqErr *RequiredFieldError
+	if !errors.As(err, &reqErr) {
+		t.Error("errors.As did not find *RequiredFieldError")
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Error("errors.As did not find *ParseError")
+	}
+	if !errors.Is(err, innerErr) {
+		t.Error("errors.Is did not find the inner error")
+	}
+}
+
+func TestFieldPath_Error(t *testing.T) {
+	reqErr := &RequiredFieldError{
+		FieldName: "Host",
+		FieldPath: "Database.Primary.Host",
+		EnvName:   "PRIMARY_DB_HOST",
+	}
+	expected := "missing required field: field 'Database.Primary.Host' is required but no value was provided (env: PRIMARY_DB_HOST)"
+	if reqErr.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, reqErr.Error())
+	}
+
+	parseErr := &ParseError{
+		FieldName: "Ports[1]",
+		FieldPath: "Server.Ports[1]",
+		EnvName:   "SERVER_PORTS",
+		FieldType: "int",
+		Value:     "abc",
+		Err:       errors.New("invalid syntax"),
+	}
+	expected = "failed to parse value 'abc' as int for field 'Server.Ports[1]' (env: SERVER_PORTS): invalid syntax"
+	if parseErr.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, parseErr.Error())
+	}
+}
+
+func TestTagSyntaxError_Error(t *testing.T) {
+	err := &TagSyntaxError{
+		FieldName: "Host",
+		FieldPath: "Database.Host",
+		Tag:       "env=HOST,default='x",
+		Msg:       "unterminated quoted value",
+	}
+
+	expected := "invalid struct tag: field 'Database.Host': unterminated quoted value (tag: `env=HOST,default='x`)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrInvalidTag) {
+		t.Error("TagSyntaxError does not match ErrInvalidTag")
+	}
+}
+
+func TestDotenvSyntaxError_Error(t *testing.T) {
+	err := &DotenvSyntaxError{
+		File: "config/.env",
+		Line: 3,
+		Msg:  "missing '=' in \"HOST\"",
+	}
+
+	expected := "invalid dotenv file: config/.env:3: missing '=' in \"HOST\""
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrInvalidDotenv) {
+		t.Error("DotenvSyntaxError does not match ErrInvalidDotenv")
+	}
+}
+
+func TestInterpolationError_Error(t *testing.T) {
+	err := &InterpolationError{
+		FieldName: "URL",
+		FieldPath: "Database.URL",
+		EnvName:   "DB_URL",
+		Chain:     []string{"DB_URL", "DB_PASSWORD"},
+		Err:       fmt.Errorf("%w: DB_PASSWORD: must be set", ErrUnsetReference),
+	}
+
+	expected := "failed to expand value for field 'Database.URL' (env: DB_URL): required variable reference is not set: DB_PASSWORD: must be set (reference chain: DB_URL -> DB_PASSWORD)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrUnsetReference) {
+		t.Error("InterpolationError does not match ErrUnsetReference")
+	}
+}
+
+func TestConflictError_Error(t *testing.T) {
+	err := &ConflictError{
+		FieldName: "Password",
+		FieldPath: "Database.Password",
+		EnvNames:  []string{"DB_PASSWORD", "DB_PASSWORD_FILE"},
+	}
+
+	expected := "conflicting values: field 'Database.Password' is set by more than one variable: DB_PASSWORD, DB_PASSWORD_FILE"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrConflict) {
+		t.Error("ConflictError does not match ErrConflict")
+	}
+}
+
+func TestFileError_Error(t *testing.T) {
+	err := &FileError{
+		FieldName: "Password",
+		FieldPath: "Database.Password",
+		EnvName:   "DB_PASSWORD_FILE",
+		Path:      "/run/secrets/db",
+		Err:       os.ErrPermission,
+	}
+
+	expected := "failed to read file '/run/secrets/db' for field 'Database.Password' (env: DB_PASSWORD_FILE): permission denied"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, os.ErrPermission) {
+		t.Error("FileError does not match os.ErrPermission")
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{
+		FieldName: "Port",
+		FieldPath: "Server.Port",
+		EnvName:   "SERVER_PORT",
+		Rule:      "max=65535",
+		Value:     "70000",
+	}
+
+	expected := "validation failed for field 'Server.Port' (env: SERVER_PORT): value '70000' does not satisfy max=65535"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("ValidationError does not match ErrValidation")
+	}
+}
+
+func TestStructValidationError_Error(t *testing.T) {
+	cause := errors.New("TLSCert requires TLSKey")
+	err := &StructValidationError{FieldPath: "Server.TLS", Err: cause}
+
+	expected := "validation failed for struct 'Server.TLS': TLSCert requires TLSKey"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrValidation) || !errors.Is(err, cause) {
+		t.Error("StructValidationError does not match ErrValidation and its cause")
+	}
+
+	err = &StructValidationError{Err: cause}
+	expected = "validation failed: TLSCert requires TLSKey"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+}
+
+func TestRequiredFieldError_Condition(t *testing.T) {
+	err := &RequiredFieldError{
+		FieldName: "Bucket",
+		FieldPath: "Storage.Bucket",
+		EnvName:   "S3_BUCKET",
+		Condition: "when 'Storage.Kind' is 's3'",
+	}
+
+	expected := "missing required field: field 'Storage.Bucket' is required when 'Storage.Kind' is 's3' but no value was provided (env: S3_BUCKET)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+}
+
+func TestExcludedFieldError_Error(t *testing.T) {
+	err := &ExcludedFieldError{
+		FieldName: "Insecure",
+		FieldPath: "Server.Insecure",
+		EnvName:   "SERVER_INSECURE",
+		Condition: "when 'Server.Cert' is set",
+	}
+
+	expected := "excluded field: field 'Server.Insecure' must not be set when 'Server.Cert' is set (env: SERVER_INSECURE)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrExcludedField) {
+		t.Error("ExcludedFieldError does not match ErrExcludedField")
+	}
+}
+
+func TestGroupError_Error(t *testing.T) {
+	err := &GroupError{
+		Group:       "auth",
+		StructPath:  "Client",
+		Rule:        "exactly one",
+		EnvNames:    []string{"API_KEY", "OAUTH_TOKEN"},
+		SetEnvNames: []string{"API_KEY", "OAUTH_TOKEN"},
+	}
+
+	expected := "invalid field group: group 'auth' in 'Client' requires exactly one of API_KEY, OAUTH_TOKEN to be set (set: API_KEY, OAUTH_TOKEN)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+	if !errors.Is(err, ErrFieldGroup) {
+		t.Error("GroupError does not match ErrFieldGroup")
+	}
+
+	err = &GroupError{Group: "auth", Rule: "at least one", EnvNames: []string{"API_KEY", "OAUTH_TOKEN"}}
+	expected = "invalid field group: group 'auth' requires at least one of API_KEY, OAUTH_TOKEN to be set (set: none)"
+	if err.Error() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, err.Error())
+	}
+}
@@ -0,0 +1,53 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// lookupFile sprawdza zmienną z nazwą envName+FileSuffix i zwraca zawartość wskazanego
+// przez nią pliku bez końcowego znaku nowej linii oraz ścieżkę pliku. Zmienna ustawiona na pusty ciąg
+// jest traktowana jak nieustawiona. Parametr hasValue informuje, czy wartość pola
+// jest już ustawiona w zmiennej envName - ustawienie obu zmiennych jest błędem.
+func (l *loader) lookupFile(envName string, hasValue bool) (string, string, bool, error) {
+	fileEnv := envName + FileSuffix
+	path, ok := l.opts.lookuper.LookupEnv(fileEnv)
+	if !ok || path == "" {
+		return "", "", false, nil
+	}
+	if hasValue {
+		return "", "", false, &ConflictError{EnvNames: []string{envName, fileEnv}}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", false, &FileError{EnvName: fileEnv, Path: path, Err: err}
+	}
+
+	return trimNewline(string(content)), path, true, nil
+}
+
+// trimNewline usuwa pojedynczy końcowy znak nowej linii ("\n" lub "\r\n"), którym zwykle
+// kończą się pliki z wartościami, a który nie jest częścią samej wartości
+func trimNewline(value string) string {
+	if strings.HasSuffix(value, "\n") {
+		return strings.TrimSuffix(strings.TrimSuffix(value, "\n"), "\r")
+	}
+	return value
+}
+
+// fileError uzupełnia błąd zwrócony przez lookupValue o nazwę i pełną ścieżkę pola
+func fileError(err error, fieldName string, fieldPath string) error {
+	var conflictErr *ConflictError
+	if errors.As(err, &conflictErr) {
+		conflictErr.FieldName = fieldName
+		conflictErr.FieldPath = fieldPath
+	}
+	var fileErr *FileError
+	if errors.As(err, &fileErr) {
+		fileErr.FieldName = fieldName
+		fileErr.FieldPath = fieldPath
+	}
+	return err
+}
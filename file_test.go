@@ -0,0 +1,141 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSecret zapisuje plik z wartością w katalogu tymczasowym testu i zwraca jego ścieżkę
+func writeSecret(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+// TestLoadWith_FileValues sprawdza odczyt wartości z plików wskazanych przez zmienne _FILE
+func TestLoadWith_FileValues(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Password string `envconfig:"env=DB_PASSWORD"`
+		Token    string `envconfig:"env=API_TOKEN"`
+		Key      []byte `envconfig:"env=TLS_KEY"`
+		Port     int    `envconfig:"env=DB_PORT,default=5432"`
+		User     string `envconfig:"env=DB_USER,file=false"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"DB_PASSWORD_FILE": writeSecret(t, "password", "s3cr3t\n"),
+		"API_TOKEN_FILE":   writeSecret(t, "token", "abc\r\n"),
+		"TLS_KEY_FILE":     writeSecret(t, "key", "line1\nline2\n\n"),
+		"DB_PORT_FILE":     "",
+		"DB_USER":          "admin",
+		"DB_USER_FILE":     "/does/not/matter",
+	})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(source), WithFileValues()); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if cfg.Password != "s3cr3t" {
+		t.Errorf("Password = %q, want %q", cfg.Password, "s3cr3t")
+	}
+	if cfg.Token != "abc" {
+		t.Errorf("Token = %q, want %q", cfg.Token, "abc")
+	}
+	if string(cfg.Key) != "line1\nline2\n" {
+		t.Errorf("Key = %q, want %q", cfg.Key, "line1\nline2\n")
+	}
+	if cfg.Port != 5432 {
+		t.Errorf("Port = %d, want %d", cfg.Port, 5432)
+	}
+	if cfg.User != "admin" {
+		t.Errorf("User = %q, want %q", cfg.User, "admin")
+	}
+
+	// Bez opcji WithFileValues konwencja działa tylko dla pól z kluczem file
+	type OptIn struct {
+		Password string `envconfig:"env=DB_PASSWORD,file"`
+		Token    string `envconfig:"env=API_TOKEN"`
+	}
+	var optIn OptIn
+	if err := LoadWith(&optIn, WithLookuper(source)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if optIn.Password != "s3cr3t" || optIn.Token != "" {
+		t.Errorf("got %+v, want Password from file and empty Token", optIn)
+	}
+}
+
+// TestLoadWith_FileValuesErrors sprawdza błędy konfliktu zmiennych i odczytu pliku
+func TestLoadWith_FileValuesErrors(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Database struct {
+			Password string `envconfig:"env=DB_PASSWORD,file"`
+			Token    string `envconfig:"env=DB_TOKEN,file"`
+		}
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	source := MapLookuper(map[string]string{
+		"DB_PASSWORD":      "plain",
+		"DB_PASSWORD_FILE": writeSecret(t, "password", "s3cr3t"),
+		"DB_TOKEN_FILE":    missing,
+	})
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(source))
+
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("LoadWith() error type = %T, want *ConflictError", err)
+	}
+	want := "conflicting values: field 'Database.Password' is set by more than one variable: DB_PASSWORD, DB_PASSWORD_FILE"
+	if conflictErr.Error() != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, conflictErr.Error())
+	}
+
+	var fileErr *FileError
+	if !errors.As(err, &fileErr) {
+		t.Fatalf("LoadWith() error type = %T, want *FileError", err)
+	}
+	if fileErr.FieldPath != "Database.Token" || fileErr.EnvName != "DB_TOKEN_FILE" || fileErr.Path != missing {
+		t.Errorf("FileError = %+v", fileErr)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Error("error does not match os.ErrNotExist")
+	}
+}
+
+// TestLoadWith_FileValuesAllowEmpty sprawdza, że pusta zmienna koliduje z plikiem tylko w trybie allowEmpty
+func TestLoadWith_FileValuesAllowEmpty(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Password string `envconfig:"env=DB_PASSWORD,file"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"DB_PASSWORD":      "",
+		"DB_PASSWORD_FILE": writeSecret(t, "password", "s3cr3t\n"),
+	})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(source)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if cfg.Password != "s3cr3t" {
+		t.Errorf("Password = %q, want %q", cfg.Password, "s3cr3t")
+	}
+
+	if err := LoadWith(&cfg, WithLookuper(source), WithAllowEmpty()); !errors.Is(err, ErrConflict) {
+		t.Errorf("LoadWith() error = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,90 @@
+package envconfig
+
+import "fmt"
+
+// fieldGroup opisuje grupę pól struktury oznaczonych tą samą nazwą w kluczu group
+type fieldGroup struct {
+	name      string
+	exclusive bool     // Dokładnie jedno pole grupy musi otrzymać wartość; w przeciwnym razie co najmniej jedno
+	fields    []int    // Indeksy pól grupy w strukturze
+	envNames  []string // Nazwy zmiennych pól grupy
+}
+
+// rule zwraca opis reguły grupy używany w komunikacie błędu
+func (g *fieldGroup) rule() string {
+	if g.exclusive {
+		return "exactly one"
+	}
+	return "at least one"
+}
+
+// addGroupMember dodaje pole do grupy z klucza group, tworząc grupę przy pierwszym polu.
+// Każde pole grupy musi określać jej regułę (exclusive - dokładnie jedno pole, lub
+// atleastone - co najmniej jedno pole) i reguła ta musi być taka sama dla wszystkich pól.
+// W razie błędu zwracane są niezmienione grupy.
+func addGroupMember(groups []*fieldGroup, tagMap map[string]string, index int, envName string) ([]*fieldGroup, error) {
+	exclusive := tagBool(tagMap, ExclusiveKey, false)
+	atLeastOne := tagBool(tagMap, AtLeastOneKey, false)
+
+	name, ok := tagMap[GroupKey]
+	if !ok {
+		if exclusive || atLeastOne {
+			return groups, fmt.Errorf("keys %q and %q require key %q", ExclusiveKey, AtLeastOneKey, GroupKey)
+		}
+		return groups, nil
+	}
+	if !exclusive && !atLeastOne {
+		return groups, fmt.Errorf("group %q requires key %q or %q", name, ExclusiveKey, AtLeastOneKey)
+	}
+	if exclusive && atLeastOne {
+		return groups, fmt.Errorf("keys %q and %q cannot be combined (%q already requires exactly one field)", ExclusiveKey, AtLeastOneKey, ExclusiveKey)
+	}
+
+	for _, g := range groups {
+		if g.name != name {
+			continue
+		}
+		if g.exclusive != exclusive {
+			return groups, fmt.Errorf("group %q is declared with rule %q by another field", name, g.rule())
+		}
+		g.fields = append(g.fields, index)
+		g.envNames = append(g.envNames, envName)
+		return groups, nil
+	}
+
+	g := &fieldGroup{
+		name:      name,
+		exclusive: exclusive,
+		fields:    []int{index},
+		envNames:  []string{envName},
+	}
+	return append(groups, g), nil
+}
+
+// checkGroups sprawdza reguły grup pól struktury po załadowaniu wszystkich jej pól.
+// Reguła dotyczy ustawionych zmiennych (sourced) - pole z wartością domyślną nie jest
+// ustawione. Grupy, w których ładowanie któregokolwiek pola się nie powiodło, są pomijane.
+func (l *loader) checkGroups(sc scope, groups []*fieldGroup, sourced []bool, marks errorMarks) {
+groups:
+	for _, g := range groups {
+		var setEnvNames []string
+		for i, index := range g.fields {
+			if marks.failed(index) {
+				continue groups
+			}
+			if sourced[index] {
+				setEnvNames = append(setEnvNames, g.envNames[i])
+			}
+		}
+
+		if (g.exclusive && len(setEnvNames) != 1) || len(setEnvNames) == 0 {
+			l.fail(&GroupError{
+				Group:       g.name,
+				StructPath:  sc.path,
+				Rule:        g.rule(),
+				EnvNames:    g.envNames,
+				SetEnvNames: setEnvNames,
+			})
+		}
+	}
+}
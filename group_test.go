@@ -0,0 +1,191 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// groupConfig jest konfiguracją z grupami pól używaną w testach
+type groupConfig struct {
+	DatabaseURL string `envconfig:"env=DATABASE_URL,group=db,exclusive"`
+	DBHost      string `envconfig:"env=DB_HOST,group=db,exclusive"`
+	APIKey      string `envconfig:"env=API_KEY,group=auth,atleastone"`
+	OAuthToken  string `envconfig:"env=OAUTH_TOKEN,group=auth,atleastone"`
+}
+
+// TestLoadStruct_Groups sprawdza reguły exclusive i atleastone
+func TestLoadStruct_Groups(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			values: map[string]string{"DB_HOST": "db", "API_KEY": "k", "OAUTH_TOKEN": "t"},
+		},
+		{
+			name:   "nothing set",
+			values: map[string]string{},
+			wantErr: []string{
+				"invalid field group: group 'db' requires exactly one of DATABASE_URL, DB_HOST to be set (set: none)",
+				"invalid field group: group 'auth' requires at least one of API_KEY, OAUTH_TOKEN to be set (set: none)",
+			},
+		},
+		{
+			name:   "too many set",
+			values: map[string]string{"DATABASE_URL": "postgres://db", "DB_HOST": "db", "API_KEY": "k"},
+			wantErr: []string{
+				"invalid field group: group 'db' requires exactly one of DATABASE_URL, DB_HOST to be set (set: DATABASE_URL, DB_HOST)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var cfg groupConfig
+				err := LoadWith(&cfg, WithLookuper(MapLookuper(tt.values)))
+				if len(tt.wantErr) == 0 {
+					if err != nil {
+						t.Fatalf("LoadWith() error = %v", err)
+					}
+					return
+				}
+
+				var loadErrs LoadErrors
+				if !errors.As(err, &loadErrs) {
+					t.Fatalf("LoadWith() error type = %T, want LoadErrors", err)
+				}
+				var got []string
+				for _, groupErr := range loadErrs {
+					got = append(got, groupErr.Error())
+				}
+				if !reflect.DeepEqual(got, tt.wantErr) {
+					t.Errorf("LoadWith() errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErr, "\n"))
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_GroupError sprawdza pola błędu grupy w strukturze zagnieżdżonej
+func TestLoadStruct_GroupError(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Database struct {
+			URL  string `envconfig:"env=URL,group=target,exclusive"`
+			Host string `envconfig:"env=HOST,default=localhost,group=target,exclusive"`
+			Port int    `envconfig:"env=PORT,group=port,atleastone"`
+		} `envconfig:"prefix=DB_"`
+	}
+
+	// Wartość domyślna nie oznacza ustawionej zmiennej
+	var cfg Config
+	source := map[string]string{"DB_URL": "postgres://db", "DB_PORT": "5432"}
+	if err := LoadWith(&cfg, WithLookuper(MapLookuper(source))); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if cfg.Database.Host != "localhost" {
+		t.Errorf("Host = %q, want default value", cfg.Database.Host)
+	}
+
+	source["DB_HOST"] = "db"
+	err := LoadWith(&cfg, WithLookuper(MapLookuper(source)))
+	var groupErr *GroupError
+	if !errors.As(err, &groupErr) {
+		t.Fatalf("LoadWith() error type = %T, want *GroupError", err)
+	}
+	want := &GroupError{
+		Group:       "target",
+		StructPath:  "Database",
+		Rule:        "exactly one",
+		EnvNames:    []string{"DB_URL", "DB_HOST"},
+		SetEnvNames: []string{"DB_URL", "DB_HOST"},
+	}
+	if !reflect.DeepEqual(groupErr, want) {
+		t.Errorf("GroupError = %+v, want %+v", groupErr, want)
+	}
+
+	var unset Config
+	err = LoadWith(&unset, WithLookuper(MapLookuper(map[string]string{"DB_PORT": "5432"})))
+	if !errors.As(err, &groupErr) || groupErr.Group != "target" || len(groupErr.SetEnvNames) != 0 {
+		t.Errorf("LoadWith() error = %v, want GroupError with no variables set", err)
+	}
+	if !errors.Is(err, ErrFieldGroup) {
+		t.Error("error does not match ErrFieldGroup")
+	}
+
+	// Grupa z polem, którego ładowanie się nie powiodło, nie jest sprawdzana
+	var failed Config
+	err = LoadWith(&failed, WithLookuper(MapLookuper(map[string]string{"DB_URL": "postgres://db", "DB_PORT": "abc"})))
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 1 {
+		t.Fatalf("LoadWith() error = %v, want only the parse error", err)
+	}
+}
+
+// TestLoadStruct_InvalidGroups sprawdza, że niepoprawne grupy są błędami tagu
+func TestLoadStruct_InvalidGroups(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  interface{}
+		wantMsg string
+	}{
+		{
+			name: "missing rule",
+			config: &struct {
+				Field string `envconfig:"group=auth"`
+			}{},
+			wantMsg: `group "auth" requires key "exclusive" or "atleastone"`,
+		},
+		{
+			name: "rule without group",
+			config: &struct {
+				Field string `envconfig:"exclusive"`
+			}{},
+			wantMsg: `keys "exclusive" and "atleastone" require key "group"`,
+		},
+		{
+			name: "different rules",
+			config: &struct {
+				Other string `envconfig:"group=auth,exclusive"`
+				Field string `envconfig:"group=auth,atleastone"`
+			}{},
+			wantMsg: `group "auth" is declared with rule "exactly one" by another field`,
+		},
+		{
+			name: "combined rules",
+			config: &struct {
+				Field string `envconfig:"group=auth,exclusive,atleastone"`
+			}{},
+			wantMsg: `keys "exclusive" and "atleastone" cannot be combined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := LoadWith(tt.config, WithLookuper(MapLookuper(nil)))
+				var tagErr *TagSyntaxError
+				if !errors.As(err, &tagErr) {
+					t.Fatalf("LoadWith() error type = %T, want *TagSyntaxError", err)
+				}
+				if tagErr.FieldPath != "Field" || !strings.Contains(tagErr.Msg, tt.wantMsg) {
+					t.Errorf("TagSyntaxError = %+v, want message containing %q", tagErr, tt.wantMsg)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,151 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// expander rozwija odwołania ${VAR} w wartościach pól na podstawie źródła wartości.
+// Wartości zmiennych, do których prowadzą odwołania, są rozwijane rekurencyjnie (poza
+// wartościami z plików .env, rozwiniętymi już podczas wczytywania), a chain przechowuje
+// bieżący łańcuch odwołań na potrzeby wykrywania cykli.
+type expander struct {
+	lookuper Lookuper
+	chain    []string
+}
+
+// expandValue rozwija odwołania w wartości pola. Nazwa startowa (zwykle nazwa zmiennej,
+// z której pochodzi wartość) rozpoczyna łańcuch odwołań, dzięki czemu odwołanie
+// z powrotem do niej jest wykrywane jako cykl; pusta nazwa oznacza wartość domyślną z tagu.
+//
+// Obsługiwane wyrażenia:
+//   - ${VAR} - wartość zmiennej lub pusty ciąg, jeśli zmienna nie jest ustawiona
+//   - ${VAR:-fallback} - wartość zmiennej lub fallback, jeśli zmienna nie jest ustawiona lub jest pusta
+//   - ${VAR:?komunikat} - wartość zmiennej lub błąd z komunikatem, jeśli nie jest ustawiona lub jest pusta
+//   - $$ - dosłowny znak "$"
+//
+// Znak "$", po którym nie występuje "{" ani "$", jest zachowywany bez zmian.
+// Błędy są zwracane jako *InterpolationError z uzupełnionym polem Chain.
+func expandValue(l Lookuper, start string, value string) (string, error) {
+	e := &expander{lookuper: l}
+	if start != "" {
+		e.chain = append(e.chain, start)
+	}
+	return e.expand(value)
+}
+
+// fail tworzy błąd z kopią bieżącego łańcucha odwołań uzupełnionego o podane nazwy
+func (e *expander) fail(err error, names ...string) error {
+	chain := append(append([]string(nil), e.chain...), names...)
+	return &InterpolationError{Chain: chain, Err: err}
+}
+
+// expand rozwija wszystkie odwołania w wartości
+func (e *expander) expand(value string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+
+		switch value[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := closingBrace(value, i+2)
+			if end < 0 {
+				return "", e.fail(fmt.Errorf("unterminated variable reference in %q", value))
+			}
+			resolved, err := e.resolve(value[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(resolved)
+			i = end
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// resolve zwraca wartość wyrażenia z wnętrza ${...}
+func (e *expander) resolve(expr string) (string, error) {
+	name, operator, arg := expr, "", ""
+	if i := strings.IndexByte(expr, ':'); i >= 0 {
+		name = expr[:i]
+		if len(expr) < i+2 || (expr[i+1] != '-' && expr[i+1] != '?') {
+			return "", e.fail(fmt.Errorf("unsupported variable expression ${%s}", expr))
+		}
+		operator, arg = expr[i:i+2], expr[i+2:]
+	}
+	if !isDotenvKey(name) {
+		return "", e.fail(fmt.Errorf("invalid variable reference ${%s}", expr))
+	}
+
+	for _, seen := range e.chain {
+		if seen == name {
+			return "", e.fail(ErrReferenceCycle, name)
+		}
+	}
+
+	value, expanded, found := lookupExpanded(e.lookuper, name)
+	if found && value != "" {
+		// Wartości z plików .env zostały już rozwinięte podczas ich wczytywania
+		if expanded {
+			return value, nil
+		}
+		// Wartość zmiennej może zawierać kolejne odwołania
+		e.chain = append(e.chain, name)
+		expanded, err := e.expand(value)
+		e.chain = e.chain[:len(e.chain)-1]
+		return expanded, err
+	}
+
+	switch operator {
+	case ":-":
+		return e.expand(arg)
+	case ":?":
+		if arg == "" {
+			arg = "variable is not set or empty"
+		}
+		return "", e.fail(fmt.Errorf("%w: %s: %s", ErrUnsetReference, name, arg), name)
+	}
+	return "", nil
+}
+
+// closingBrace zwraca indeks nawiasu zamykającego wyrażenie rozpoczęte przed pozycją start,
+// uwzględniając zagnieżdżone odwołania w wartościach zastępczych, lub -1, jeśli go brak
+func closingBrace(value string, start int) int {
+	depth := 0
+	for i := start; i < len(value); i++ {
+		switch value[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// interpolationError uzupełnia błąd rozwijania odwołań o nazwę, ścieżkę pola i nazwę zmiennej
+func interpolationError(err error, fieldName string, fieldPath string, envName string) error {
+	var expandErr *InterpolationError
+	if errors.As(err, &expandErr) {
+		expandErr.FieldName = fieldName
+		expandErr.FieldPath = fieldPath
+		expandErr.EnvName = envName
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestExpandValue sprawdza rozwijanie odwołań ${VAR} i ich wariantów
+func TestExpandValue(t *testing.T) {
+	t.Parallel()
+
+	source := MapLookuper(map[string]string{
+		"DB_HOST":  "db.local",
+		"DB_PORT":  "5432",
+		"DB_URL":   "postgres://${DB_HOST}:${DB_PORT}",
+		"APP_HOME": "/opt/app",
+		"EMPTY":    "",
+	})
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "no references", value: "plain value", want: "plain value"},
+		{name: "simple", value: "${APP_HOME}/data", want: "/opt/app/data"},
+		{name: "nested values", value: "url=${DB_URL}/app", want: "url=postgres://db.local:5432/app"},
+		{name: "unset", value: "[${MISSING}]", want: "[]"},
+		{name: "fallback unset", value: "${MISSING:-/tmp}", want: "/tmp"},
+		{name: "fallback empty", value: "${EMPTY:-none}", want: "none"},
+		{name: "fallback not used", value: "${DB_PORT:-1}", want: "5432"},
+		{name: "fallback with reference", value: "${MISSING:-${APP_HOME}/cache}", want: "/opt/app/cache"},
+		{name: "required set", value: "${DB_HOST:?host is required}", want: "db.local"},
+		{name: "escaped dollar", value: "price: $$5 $${APP_HOME}", want: "price: $5 ${APP_HOME}"},
+		{name: "lone dollar", value: "$HOME costs 5$", want: "$HOME costs 5$"},
+		{name: "repeated reference", value: "${DB_PORT}-${DB_PORT}", want: "5432-5432"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := expandValue(source, "", tt.value)
+				if err != nil {
+					t.Fatalf("expandValue() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("expandValue() = %q, want %q", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+// TestExpandValue_Errors sprawdza błędy rozwijania wraz z łańcuchem odwołań
+func TestExpandValue_Errors(t *testing.T) {
+	t.Parallel()
+
+	source := MapLookuper(map[string]string{
+		"A":    "${B}",
+		"B":    "x${C}",
+		"C":    "${A}",
+		"SELF": "${SELF}",
+		"NEED": "${PASSWORD:?must be set}",
+	})
+
+	tests := []struct {
+		name      string
+		start     string
+		value     string
+		wantErr   error
+		wantChain []string
+		wantMsg   string
+	}{
+		{
+			name: "cycle", value: "${A}",
+			wantErr: ErrReferenceCycle, wantChain: []string{"A", "B", "C", "A"},
+		},
+		{
+			name: "cycle through field variable", start: "C", value: "${A}",
+			wantErr: ErrReferenceCycle, wantChain: []string{"C", "A", "B", "C"},
+		},
+		{
+			name: "self reference", value: "${SELF}",
+			wantErr: ErrReferenceCycle, wantChain: []string{"SELF", "SELF"},
+		},
+		{
+			name: "required reference", start: "DSN", value: "${NEED}",
+			wantErr: ErrUnsetReference, wantChain: []string{"DSN", "NEED", "PASSWORD"}, wantMsg: "PASSWORD: must be set",
+		},
+		{name: "unterminated", value: "${A", wantMsg: "unterminated variable reference"},
+		{name: "invalid name", value: "${1A}", wantMsg: "invalid variable reference"},
+		{name: "unsupported operator", value: "${A:+x}", wantMsg: "unsupported variable expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := expandValue(source, tt.start, tt.value)
+				var expandErr *InterpolationError
+				if !errors.As(err, &expandErr) {
+					t.Fatalf("expandValue() error type = %T, want *InterpolationError", err)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("expandValue() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantChain != nil && !reflect.DeepEqual(expandErr.Chain, tt.wantChain) {
+					t.Errorf("Chain = %q, want %q", expandErr.Chain, tt.wantChain)
+				}
+				if !strings.Contains(err.Error(), tt.wantMsg) {
+					t.Errorf("error = %q, want it to contain %q", err.Error(), tt.wantMsg)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadWith_Expand sprawdza rozwijanie odwołań w wartościach i wartościach domyślnych
+func TestLoadWith_Expand(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		DataDir string `envconfig:"env=DATA_DIR,default=${APP_HOME}/data"`
+		DBURL   string `envconfig:"env=DB_URL"`
+		Raw     string `envconfig:"env=RAW,expand=false"`
+		Cache   string `envconfig:"env=CACHE_DIR,default=${CACHE_HOME:-/tmp}/cache"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"APP_HOME": "/opt/app",
+		"DB_HOST":  "db",
+		"DB_PORT":  "5432",
+		"DB_URL":   "postgres://${DB_HOST}:${DB_PORT}",
+		"RAW":      "${DB_HOST}",
+	})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(source), WithExpand()); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	want := Config{
+		DataDir: "/opt/app/data",
+		DBURL:   "postgres://db:5432",
+		Raw:     "${DB_HOST}",
+		Cache:   "/tmp/cache",
+	}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+
+	// Bez opcji WithExpand rozwijane są tylko pola z kluczem expand
+	type OptIn struct {
+		Plain    string `envconfig:"env=DB_URL"`
+		Expanded string `envconfig:"env=DB_URL,expand"`
+	}
+	var optIn OptIn
+	if err := LoadWith(&optIn, WithLookuper(source)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if optIn.Plain != "postgres://${DB_HOST}:${DB_PORT}" || optIn.Expanded != "postgres://db:5432" {
+		t.Errorf("got %+v", optIn)
+	}
+}
+
+// TestLoadWith_ExpandErrors sprawdza, że błędy rozwijania wskazują pole i zmienną
+func TestLoadWith_ExpandErrors(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Database struct {
+			URL string `envconfig:"env=DB_URL"`
+		}
+	}
+
+	source := MapLookuper(map[string]string{
+		"DB_URL":  "postgres://${DB_HOST}",
+		"DB_HOST": "${DB_URL}",
+	})
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(source), WithExpand())
+	var expandErr *InterpolationError
+	if !errors.As(err, &expandErr) {
+		t.Fatalf("LoadWith() error type = %T, want *InterpolationError", err)
+	}
+	if expandErr.FieldPath != "Database.URL" || expandErr.EnvName != "DB_URL" {
+		t.Errorf("error field = %s (env: %s), want Database.URL (env: DB_URL)", expandErr.FieldPath, expandErr.EnvName)
+	}
+	want := "failed to expand value for field 'Database.URL' (env: DB_URL): cyclic variable reference (reference chain: DB_URL -> DB_HOST -> DB_URL)"
+	if expandErr.Error() != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, expandErr.Error())
+	}
+}
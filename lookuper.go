@@ -0,0 +1,171 @@
+package envconfig
+
+import (
+	"os"
+	"strings"
+)
+
+// Lookuper jest źródłem wartości konfiguracyjnych.
+// Metoda LookupEnv działa analogicznie do os.LookupEnv - zwraca wartość dla podanego klucza
+// oraz informację, czy klucz w ogóle istnieje w źródle.
+type Lookuper interface {
+	LookupEnv(key string) (string, bool)
+}
+
+// SourceLookuper jest opcjonalnym rozszerzeniem interfejsu Lookuper dla źródeł, które potrafią
+// wskazać pochodzenie wartości, np. plik .env, z którego została wczytana. Nazwa źródła
+// trafia do raportu pochodzenia wartości (zob. WithReport). Źródła, które nie implementują
+// tego interfejsu, są raportowane jako SourceEnv.
+type SourceLookuper interface {
+	Lookuper
+	LookupSource(key string) (value string, source string, ok bool)
+}
+
+// lookupSource pobiera wartość wraz z nazwą jej źródła
+func lookupSource(l Lookuper, key string) (string, string, bool) {
+	if sl, ok := l.(SourceLookuper); ok {
+		return sl.LookupSource(key)
+	}
+	value, ok := l.LookupEnv(key)
+	return value, SourceEnv, ok
+}
+
+// expandedLookuper jest implementowany przez źródła, których wartości mają już rozwinięte
+// odwołania ${VAR} (np. pliki .env), więc WithExpand nie może rozwijać ich ponownie
+type expandedLookuper interface {
+	lookupExpanded(key string) (value string, expanded bool, ok bool)
+}
+
+// lookupExpanded pobiera wartość wraz z informacją, czy jej odwołania zostały już rozwinięte
+func lookupExpanded(l Lookuper, key string) (string, bool, bool) {
+	if el, ok := l.(expandedLookuper); ok {
+		return el.lookupExpanded(key)
+	}
+	value, ok := l.LookupEnv(key)
+	return value, false, ok
+}
+
+// osLookuper odczytuje wartości ze zmiennych środowiskowych procesu
+type osLookuper struct{}
+
+// LookupEnv implementuje interfejs Lookuper
+func (osLookuper) LookupEnv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// OsLookuper zwraca Lookuper odczytujący wartości ze zmiennych środowiskowych procesu.
+// Jest to domyślne źródło używane przez Load.
+func OsLookuper() Lookuper {
+	return osLookuper{}
+}
+
+// mapLookuper odczytuje wartości z mapy
+type mapLookuper map[string]string
+
+// LookupEnv implementuje interfejs Lookuper
+func (m mapLookuper) LookupEnv(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// MapLookuper zwraca Lookuper odczytujący wartości z podanej mapy.
+// Przydatny głównie w testach, ponieważ nie modyfikuje środowiska procesu.
+func MapLookuper(m map[string]string) Lookuper {
+	return mapLookuper(m)
+}
+
+// SliceLookuper zwraca Lookuper odczytujący wartości z listy w formacie "KLUCZ=WARTOŚĆ",
+// takiej jak zwracana przez os.Environ. Wpisy bez znaku "=" są ignorowane,
+// a w przypadku powtórzonych kluczy wygrywa ostatnie wystąpienie.
+func SliceLookuper(environ []string) Lookuper {
+	m := make(map[string]string, len(environ))
+	for _, entry := range environ {
+		// Podział na klucz i wartość przy pierwszym znaku "="
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		m[key] = value
+	}
+	return mapLookuper(m)
+}
+
+// chain odpytuje źródła po kolei i zwraca pierwszą znalezioną wartość
+type chain []Lookuper
+
+// LookupEnv implementuje interfejs Lookuper
+func (c chain) LookupEnv(key string) (string, bool) {
+	for _, l := range c {
+		if value, ok := l.LookupEnv(key); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// LookupSource implementuje interfejs SourceLookuper - źródłem jest nazwa warstwy,
+// w której znaleziono wartość
+func (c chain) LookupSource(key string) (string, string, bool) {
+	for _, l := range c {
+		if value, source, ok := lookupSource(l, key); ok {
+			return value, source, true
+		}
+	}
+	return "", "", false
+}
+
+// lookupExpanded implementuje interfejs expandedLookuper dla warstwy, w której znaleziono wartość
+func (c chain) lookupExpanded(key string) (string, bool, bool) {
+	for _, l := range c {
+		if value, expanded, ok := lookupExpanded(l, key); ok {
+			return value, expanded, true
+		}
+	}
+	return "", false, false
+}
+
+// Chain łączy źródła w jedno źródło warstwowe. Źródła są odpytywane w podanej kolejności,
+// a wygrywa pierwsze, w którym klucz istnieje - pierwsze źródło ma więc najwyższy priorytet,
+// a ostatnie najniższy. Zmienna ustawiona na pusty ciąg również jest trafieniem, więc nie
+// przepuszcza wyszukiwania do kolejnych warstw. Źródła równe nil są pomijane.
+//
+//	source := envconfig.Chain(
+//		flags,                       // flagi wiersza poleceń
+//		envconfig.OsLookuper(),      // zmienne środowiskowe procesu
+//		overlay,                     // plik dla środowiska, np. .env.production
+//		base,                        // plik bazowy z repozytorium
+//	)
+//
+// Wartości domyślne z tagów są używane dopiero wtedy, gdy klucz nie występuje w żadnej warstwie.
+func Chain(sources ...Lookuper) Lookuper {
+	c := make(chain, 0, len(sources))
+	for _, source := range sources {
+		if source != nil {
+			c = append(c, source)
+		}
+	}
+	return c
+}
+
+// named nadaje źródłu nazwę używaną w raporcie pochodzenia wartości
+type named struct {
+	name string
+	Lookuper
+}
+
+// LookupSource implementuje interfejs SourceLookuper
+func (n named) LookupSource(key string) (string, string, bool) {
+	value, ok := n.LookupEnv(key)
+	return value, n.name, ok
+}
+
+// lookupExpanded implementuje interfejs expandedLookuper dla nazwanego źródła
+func (n named) lookupExpanded(key string) (string, bool, bool) {
+	return lookupExpanded(n.Lookuper, key)
+}
+
+// Named nadaje źródłu nazwę, pod którą jego wartości są widoczne w raporcie pochodzenia
+// wartości (zob. WithReport), np. Named("flags", flagSource) w łańcuchu Chain.
+func Named(name string, l Lookuper) Lookuper {
+	return named{name: name, Lookuper: l}
+}
@@ -0,0 +1,139 @@
+package envconfig
+
+import (
+	"os"
+	"testing"
+)
+
+// TestOsLookuper sprawdza odczyt zmiennych środowiskowych procesu
+func TestOsLookuper(t *testing.T) {
+	t.Setenv("TEST_OS_LOOKUPER", "value")
+
+	value, ok := OsLookuper().LookupEnv("TEST_OS_LOOKUPER")
+	if !ok || value != "value" {
+		t.Errorf("LookupEnv() = (%v, %v), want (%v, %v)", value, ok, "value", true)
+	}
+
+	os.Unsetenv("TEST_OS_LOOKUPER_MISSING")
+	if _, ok := OsLookuper().LookupEnv("TEST_OS_LOOKUPER_MISSING"); ok {
+		t.Errorf("LookupEnv() ok = %v, want %v", ok, false)
+	}
+}
+
+// TestMapLookuper sprawdza odczyt wartości z mapy
+func TestMapLookuper(t *testing.T) {
+	l := MapLookuper(map[string]string{"KEY": "value", "EMPTY": ""})
+
+	tests := []struct {
+		key       string
+		wantValue string
+		wantOk    bool
+	}{
+		{key: "KEY", wantValue: "value", wantOk: true},
+		{key: "EMPTY", wantValue: "", wantOk: true},
+		{key: "MISSING", wantValue: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.key, func(t *testing.T) {
+				value, ok := l.LookupEnv(tt.key)
+				if value != tt.wantValue || ok != tt.wantOk {
+					t.Errorf("LookupEnv() = (%v, %v), want (%v, %v)", value, ok, tt.wantValue, tt.wantOk)
+				}
+			},
+		)
+	}
+}
+
+// TestSliceLookuper sprawdza odczyt wartości z listy w formacie KLUCZ=WARTOŚĆ
+func TestSliceLookuper(t *testing.T) {
+	l := SliceLookuper([]string{"KEY=value", "URL=http://host/?a=b", "INVALID", "KEY=override", "EMPTY="})
+
+	tests := []struct {
+		key       string
+		wantValue string
+		wantOk    bool
+	}{
+		{key: "KEY", wantValue: "override", wantOk: true},
+		{key: "URL", wantValue: "http://host/?a=b", wantOk: true},
+		{key: "EMPTY", wantValue: "", wantOk: true},
+		{key: "INVALID", wantValue: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.key, func(t *testing.T) {
+				value, ok := l.LookupEnv(tt.key)
+				if value != tt.wantValue || ok != tt.wantOk {
+					t.Errorf("LookupEnv() = (%v, %v), want (%v, %v)", value, ok, tt.wantValue, tt.wantOk)
+				}
+			},
+		)
+	}
+}
+
+// TestChain sprawdza kolejność odpytywania źródeł w łańcuchu
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	flags := MapLookuper(map[string]string{"PORT": "9090"})
+	env := MapLookuper(map[string]string{"PORT": "8080", "HOST": "env-host", "DEBUG": ""})
+	base := MapLookuper(map[string]string{"HOST": "base-host", "DEBUG": "true", "NAME": "app"})
+
+	l := Chain(flags, nil, env, base)
+
+	tests := []struct {
+		key       string
+		wantValue string
+		wantOk    bool
+	}{
+		{key: "PORT", wantValue: "9090", wantOk: true},
+		{key: "HOST", wantValue: "env-host", wantOk: true},
+		{key: "DEBUG", wantValue: "", wantOk: true},
+		{key: "NAME", wantValue: "app", wantOk: true},
+		{key: "MISSING", wantValue: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		value, ok := l.LookupEnv(tt.key)
+		if value != tt.wantValue || ok != tt.wantOk {
+			t.Errorf("LookupEnv(%q) = (%v, %v), want (%v, %v)", tt.key, value, ok, tt.wantValue, tt.wantOk)
+		}
+	}
+
+	if _, ok := Chain().LookupEnv("PORT"); ok {
+		t.Error("empty Chain found a value")
+	}
+}
+
+// TestLoadWith_Chain sprawdza, że tagi działają tak samo dla wartości z różnych warstw
+func TestLoadWith_Chain(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Server struct {
+			Host string `envconfig:"env=HOST,default=localhost"`
+			Port int    `envconfig:"env=PORT,required"`
+		} `envconfig:"prefix=SERVER_"`
+		Tags    []string `envconfig:"env=TAGS,separator=;"`
+		Timeout string   `envconfig:"env=TIMEOUT,default=30s"`
+	}
+
+	overlay := MapLookuper(map[string]string{"SERVER_PORT": "443", "TAGS": "prod;eu"})
+	base := MapLookuper(map[string]string{"SERVER_PORT": "8080", "SERVER_HOST": "example.com", "TAGS": "dev"})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(Chain(overlay, base))); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if cfg.Server.Host != "example.com" || cfg.Server.Port != 443 {
+		t.Errorf("Server = %+v, want {Host:example.com Port:443}", cfg.Server)
+	}
+	if len(cfg.Tags) != 2 || cfg.Tags[0] != "prod" || cfg.Tags[1] != "eu" {
+		t.Errorf("Tags = %q, want [prod eu]", cfg.Tags)
+	}
+	if cfg.Timeout != "30s" {
+		t.Errorf("Timeout = %q, want %q", cfg.Timeout, "30s")
+	}
+}
@@ -0,0 +1,64 @@
+package envconfig
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Naming określa sposób wyprowadzania nazwy zmiennej środowiskowej z nazwy pola,
+// gdy w tagu nie podano klucza env
+type Naming int
+
+const (
+	// NamingUpper zamienia nazwę pola na wielkie litery (MaxIdleConns -> MAXIDLECONNS)
+	NamingUpper Naming = iota
+	// NamingSnake zamienia nazwę pola na SNAKE_CASE (MaxIdleConns -> MAX_IDLE_CONNS, HTTPTimeout -> HTTP_TIMEOUT)
+	NamingSnake
+)
+
+// Wartości klucza naming w tagu
+const (
+	namingUpperValue = "upper"
+	namingSnakeValue = "snake"
+)
+
+// parseNaming zamienia wartość klucza naming z tagu na Naming
+func parseNaming(value string) (Naming, error) {
+	switch value {
+	case namingUpperValue:
+		return NamingUpper, nil
+	case namingSnakeValue:
+		return NamingSnake, nil
+	}
+	return NamingUpper, fmt.Errorf("invalid naming %q, want %q or %q", value, namingUpperValue, namingSnakeValue)
+}
+
+// envName wyprowadza nazwę zmiennej środowiskowej z nazwy pola
+func (n Naming) envName(fieldName string) string {
+	if n == NamingSnake {
+		return toSnakeCase(fieldName)
+	}
+	return strings.ToUpper(fieldName)
+}
+
+// toSnakeCase zamienia nazwę w CamelCase na SNAKE_CASE z zachowaniem akronimów:
+// MaxIdleConns -> MAX_IDLE_CONNS, HTTPTimeout -> HTTP_TIMEOUT, UserID -> USER_ID.
+// Podkreślenie jest wstawiane przed wielką literą, jeśli poprzedza ją mała litera lub cyfra,
+// albo jeśli kończy ona akronim (poprzednia litera jest wielka, a następna mała).
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
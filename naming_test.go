@@ -0,0 +1,147 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestToSnakeCase sprawdza zamianę nazw pól na SNAKE_CASE
+func TestToSnakeCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Port", expected: "PORT"},
+		{name: "MaxIdleConns", expected: "MAX_IDLE_CONNS"},
+		{name: "HTTPTimeout", expected: "HTTP_TIMEOUT"},
+		{name: "UserID", expected: "USER_ID"},
+		{name: "ID", expected: "ID"},
+		{name: "APIKey", expected: "API_KEY"},
+		{name: "TLSCertFile", expected: "TLS_CERT_FILE"},
+		{name: "Sha256Sum", expected: "SHA256_SUM"},
+		{name: "V2API", expected: "V2_API"},
+		{name: "already_snake", expected: "ALREADY_SNAKE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if result := toSnakeCase(tt.name); result != tt.expected {
+					t.Errorf("toSnakeCase() = %v, want %v", result, tt.expected)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_Naming sprawdza wyprowadzanie nazw zmiennych z nazw pól
+func TestLoadStruct_Naming(t *testing.T) {
+	t.Parallel()
+
+	type Pool struct {
+		MaxIdleConns int
+	}
+
+	type Database struct {
+		HTTPTimeout int
+		Pool        Pool
+		Legacy      Pool `envconfig:"naming=upper"`
+		Replica     Pool `envconfig:"prefix=REPLICA_"`
+	}
+
+	type Config struct {
+		MaxIdleConns int
+		Explicit     int `envconfig:"env=EXPLICIT_NAME"`
+		Database     Database
+		Pool
+	}
+
+	source := MapLookuper(map[string]string{
+		"MAX_IDLE_CONNS":                  "1",
+		"EXPLICIT_NAME":                   "2",
+		"HTTP_TIMEOUT":                    "3",
+		"MAXIDLECONNS":                    "4",
+		"REPLICA_MAX_IDLE_CONNS":          "5",
+		"DATABASE_HTTP_TIMEOUT":           "6",
+		"DATABASE_POOL_MAX_IDLE_CONNS":    "7",
+		"DATABASE_LEGACY_MAXIDLECONNS":    "8",
+		"DATABASE_REPLICA_MAX_IDLE_CONNS": "9",
+		"DATABASE_EXPLICIT_NAME":          "10",
+	})
+
+	t.Run(
+		"Snake case", func(t *testing.T) {
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(source), WithNaming(NamingSnake))
+			if err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if cfg.MaxIdleConns != 1 || cfg.Explicit != 2 || cfg.Database.HTTPTimeout != 3 {
+				t.Errorf("cfg = %+v, want MaxIdleConns=1, Explicit=2, Database.HTTPTimeout=3", cfg)
+			}
+			if cfg.Database.Pool.MaxIdleConns != 1 {
+				t.Errorf("Database.Pool.MaxIdleConns = %v, want %v", cfg.Database.Pool.MaxIdleConns, 1)
+			}
+			if cfg.Database.Legacy.MaxIdleConns != 4 {
+				t.Errorf("Database.Legacy.MaxIdleConns = %v, want %v", cfg.Database.Legacy.MaxIdleConns, 4)
+			}
+			if cfg.Database.Replica.MaxIdleConns != 5 {
+				t.Errorf("Database.Replica.MaxIdleConns = %v, want %v", cfg.Database.Replica.MaxIdleConns, 5)
+			}
+			if cfg.Pool.MaxIdleConns != 1 {
+				t.Errorf("Pool.MaxIdleConns = %v, want %v", cfg.Pool.MaxIdleConns, 1)
+			}
+		},
+	)
+
+	t.Run(
+		"Nested names", func(t *testing.T) {
+			var cfg Config
+			err := LoadStruct(
+				reflect.ValueOf(&cfg).Elem(),
+				WithLookuper(source), WithNaming(NamingSnake), WithNestedNames(),
+			)
+			if err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if cfg.Database.HTTPTimeout != 6 {
+				t.Errorf("Database.HTTPTimeout = %v, want %v", cfg.Database.HTTPTimeout, 6)
+			}
+			if cfg.Database.Pool.MaxIdleConns != 7 {
+				t.Errorf("Database.Pool.MaxIdleConns = %v, want %v", cfg.Database.Pool.MaxIdleConns, 7)
+			}
+			if cfg.Database.Legacy.MaxIdleConns != 8 {
+				t.Errorf("Database.Legacy.MaxIdleConns = %v, want %v", cfg.Database.Legacy.MaxIdleConns, 8)
+			}
+			if cfg.Database.Replica.MaxIdleConns != 9 {
+				t.Errorf("Database.Replica.MaxIdleConns = %v, want %v", cfg.Database.Replica.MaxIdleConns, 9)
+			}
+			// Struktury osadzone nie tworzą własnego poziomu nazw
+			if cfg.Pool.MaxIdleConns != 1 {
+				t.Errorf("Pool.MaxIdleConns = %v, want %v", cfg.Pool.MaxIdleConns, 1)
+			}
+		},
+	)
+
+	t.Run(
+		"Invalid naming", func(t *testing.T) {
+			type Config struct {
+				Value int `envconfig:"naming=kebab"`
+			}
+
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(source))
+
+			var tagErr *TagSyntaxError
+			if !errors.As(err, &tagErr) {
+				t.Fatalf("LoadStruct() error type = %T, want *TagSyntaxError", err)
+			}
+			if tagErr.FieldPath != "Value" {
+				t.Errorf("TagSyntaxError.FieldPath = %v, want %v", tagErr.FieldPath, "Value")
+			}
+		},
+	)
+}
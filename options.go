@@ -0,0 +1,173 @@
+package envconfig
+
+import "log/slog"
+
+// Option modyfikuje sposób ładowania konfiguracji przez LoadWith i LoadStruct
+type Option func(*options)
+
+// options przechowuje ustawienia używane podczas ładowania konfiguracji
+type options struct {
+	lookuper    Lookuper // Źródło wartości konfiguracyjnych
+	allowEmpty  bool     // Czy pusta, ale ustawiona zmienna jest traktowana jako wartość
+	prefix      string   // Prefiks dodawany do nazw wszystkich zmiennych
+	naming      Naming   // Sposób wyprowadzania nazw zmiennych z nazw pól
+	nestedNames bool     // Czy nazwy pól zagnieżdżonych struktur tworzą prefiksy nazw zmiennych
+	expand      bool     // Czy w wartościach i wartościach domyślnych rozwijane są odwołania ${VAR}
+	files       bool     // Czy wartości mogą być odczytywane z plików wskazanych przez zmienne z sufiksem _FILE
+	report      *Report  // Raport pochodzenia wartości wypełniany podczas ładowania
+
+	deprecation func(Deprecation) // Funkcja zgłaszająca użycie przestarzałych nazw zmiennych; nil oznacza slog.Default()
+
+	dotenvUnder []string // Pliki .env o niższym priorytecie niż źródło wartości
+	dotenvOver  []string // Pliki .env o wyższym priorytecie niż źródło wartości
+	err         error    // Błąd powstały podczas przygotowywania opcji (np. odczytu plików .env)
+}
+
+// newOptions tworzy ustawienia z wartościami domyślnymi i nakłada na nie podane opcje
+func newOptions(opts []Option) *options {
+	o := &options{
+		lookuper: OsLookuper(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// Pliki .env są nakładane na źródło wartości dopiero po zastosowaniu wszystkich opcji,
+	// aby kolejność WithLookuper i WithDotenv nie miała znaczenia. Odwołania ${VAR}
+	// w plikach są rozwiązywane w tym źródle, a nie w środowisku procesu.
+	if len(o.dotenvUnder) > 0 {
+		dotenv, err := loadDotenv(o.lookuper, o.dotenvUnder...)
+		if err != nil {
+			o.err = err
+			return o
+		}
+		o.lookuper = Chain(o.lookuper, dotenv)
+	}
+	if len(o.dotenvOver) > 0 {
+		dotenv, err := loadDotenv(o.lookuper, o.dotenvOver...)
+		if err != nil {
+			o.err = err
+			return o
+		}
+		o.lookuper = Chain(dotenv, o.lookuper)
+	}
+	return o
+}
+
+// WithLookuper ustawia źródło, z którego odczytywane są wartości konfiguracyjne.
+// Domyślnie używane są zmienne środowiskowe procesu (OsLookuper).
+func WithLookuper(l Lookuper) Option {
+	return func(o *options) {
+		if l != nil {
+			o.lookuper = l
+		}
+	}
+}
+
+// WithAllowEmpty włącza semantykę obecności zmiennych: zmienna ustawiona na pusty ciąg
+// (np. FOO=) jest traktowana jako wartość, a nie jako brak wartości, więc nie powoduje
+// użycia wartości domyślnej. Pojedyncze pola mogą nadpisać to ustawienie kluczem allowEmpty.
+func WithAllowEmpty() Option {
+	return func(o *options) {
+		o.allowEmpty = true
+	}
+}
+
+// WithPrefix ustawia prefiks dodawany do nazw wszystkich zmiennych środowiskowych,
+// np. WithPrefix("MYAPP_") sprawia, że pole z env=PORT jest ładowane ze zmiennej MYAPP_PORT.
+// Prefiksy zagnieżdżonych struktur (klucz prefix) są doklejane do tego prefiksu.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithNaming ustawia sposób wyprowadzania nazw zmiennych z nazw pól, które nie mają klucza env.
+// Domyślnie używane jest NamingUpper. Klucz naming w tagu pola nadpisuje to ustawienie
+// dla tego pola i - w przypadku struktury - wszystkich jej potomków.
+func WithNaming(naming Naming) Option {
+	return func(o *options) {
+		o.naming = naming
+	}
+}
+
+// WithNestedNames sprawia, że nazwy pól zagnieżdżonych struktur są doklejane do nazw
+// zmiennych ich potomków, np. pole MaxIdleConns w polu Database jest ładowane ze zmiennej
+// DATABASE_MAX_IDLE_CONNS (przy NamingSnake). Jawny klucz prefix w tagu ma pierwszeństwo.
+func WithNestedNames() Option {
+	return func(o *options) {
+		o.nestedNames = true
+	}
+}
+
+// WithExpand włącza rozwijanie odwołań ${VAR}, ${VAR:-fallback} i ${VAR:?komunikat}
+// w wartościach zmiennych i wartościach domyślnych z tagów, np. default=${APP_HOME}/data.
+// Odwołania są rozwiązywane w tym samym źródle, z którego ładowana jest konfiguracja
+// (bez prefiksu z WithPrefix), a cykle są zgłaszane jako *InterpolationError.
+// Wartości z plików .env (WithDotenv) nie są rozwijane ponownie - odwołania rozwija już
+// parser pliku, a wartości w apostrofach i zabezpieczone \$ pozostają dosłowne.
+// Pojedyncze pola mogą nadpisać to ustawienie kluczem expand.
+func WithExpand() Option {
+	return func(o *options) {
+		o.expand = true
+	}
+}
+
+// WithFileValues włącza dla wszystkich pól konwencję _FILE znaną z obrazów Dockera:
+// jeśli ustawiona jest zmienna DB_PASSWORD_FILE, wartość pola z env=DB_PASSWORD
+// jest odczytywana z pliku o podanej ścieżce (bez końcowego znaku nowej linii).
+// Ustawienie obu zmiennych jest błędem *ConflictError. Pojedyncze pola mogą
+// nadpisać to ustawienie kluczem file.
+func WithFileValues() Option {
+	return func(o *options) {
+		o.files = true
+	}
+}
+
+// WithReport sprawia, że LoadWith wypełnia podany raport informacjami o pochodzeniu
+// wartości każdego pola: nazwie zmiennej, źródle (SourceEnv, SourceDefault, SourceZero
+// lub nazwie pliku) i tym, czy pole jest wymagane. Poprzednia zawartość raportu jest
+// zastępowana. Pola, których nie udało się załadować, nie trafiają do raportu.
+func WithReport(report *Report) Option {
+	return func(o *options) {
+		o.report = report
+	}
+}
+
+// WithDotenv wczytuje podane pliki .env jako źródło o niższym priorytecie niż zmienne
+// środowiskowe (lub źródło ustawione przez WithLookuper) - wartości z plików są używane
+// tylko dla zmiennych, które nie są ustawione w środowisku. Późniejsze pliki nadpisują
+// wcześniejsze. Odwołania ${VAR} do zmiennych spoza plików są rozwiązywane w źródle
+// wartości, a nie w środowisku procesu. Błąd odczytu lub składni pliku jest zwracany przez LoadWith.
+func WithDotenv(paths ...string) Option {
+	return func(o *options) {
+		o.dotenvUnder = append(o.dotenvUnder, paths...)
+	}
+}
+
+// WithDotenvOverride działa jak WithDotenv, ale wartości z plików .env mają pierwszeństwo
+// przed zmiennymi środowiskowymi (lub źródłem ustawionym przez WithLookuper).
+func WithDotenvOverride(paths ...string) Option {
+	return func(o *options) {
+		o.dotenvOver = append(o.dotenvOver, paths...)
+	}
+}
+
+// WithDeprecationHandler ustawia funkcję wywoływaną dla każdej ustawionej zmiennej
+// o przestarzałej nazwie (klucz deprecated), np. w celu zliczania lub zgłaszania
+// ich użycia. Domyślnie ostrzeżenia są logowane przez slog.Default().
+func WithDeprecationHandler(handler func(Deprecation)) Option {
+	return func(o *options) {
+		o.deprecation = handler
+	}
+}
+
+// WithLogger sprawia, że ostrzeżenia o zmiennych o przestarzałych nazwach są logowane
+// na poziomie Warn przez podany logger zamiast slog.Default()
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.deprecation = func(d Deprecation) {
+			d.log(logger)
+		}
+	}
+}
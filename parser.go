@@ -3,8 +3,7 @@
 package envconfig
 
 import (
-	"fmt"
-	"os"
+	"errors"
 	"reflect"
 	"strconv"
 	"strings"
@@ -15,11 +14,110 @@ import (
 // Funkcja przechodzi przez wszystkie pola struktury i dla każdego pola (z tagiem "config" lub bez)
 // próbuje załadować wartość z odpowiedniej zmiennej środowiskowej lub użyć wartości domyślnej.
 // Jeśli pole jest oznaczone jako wymagane (required = true), a nie ma wartości, zwraca błąd.
-func LoadStruct(structValue reflect.Value) error {
+// Opcje pozwalają m.in. zmienić źródło wartości (zob. WithLookuper).
+// Funkcja nie przerywa działania na pierwszym błędzie pola - wszystkie błędy są zbierane
+// i zwracane razem jako LoadErrors.
+func LoadStruct(structValue reflect.Value, opts ...Option) error {
+	l := &loader{opts: newOptions(opts)}
+	if l.opts.report != nil {
+		l.opts.report.Fields = nil
+	}
+	if l.opts.err != nil {
+		return l.opts.err
+	}
+	l.loadValidated(structValue, scope{prefix: l.opts.prefix, naming: l.opts.naming}, false)
+	return l.err()
+}
+
+// scope opisuje położenie przetwarzanej struktury w całej konfiguracji
+type scope struct {
+	path   string // Kropkowana ścieżka pól Go prowadząca do struktury, np. "Database.Primary"
+	prefix string // Prefiks dodawany do nazw wszystkich zmiennych w strukturze
+	naming Naming // Sposób wyprowadzania nazw zmiennych z nazw pól
+}
+
+// loader przechowuje stan współdzielony przez całe rekurencyjne ładowanie struktury
+type loader struct {
+	opts    *options
+	errors  []error               // Błędy pól zebrane podczas ładowania
+	walking map[reflect.Type]bool // Typy struktur, przez które loader aktualnie przechodzi
+}
+
+// fail zapisuje błąd pola, aby ładowanie mogło być kontynuowane dla pozostałych pól
+func (l *loader) fail(err error) {
+	l.errors = append(l.errors, err)
+}
+
+// err zwraca zebrane błędy jako LoadErrors lub nil, jeśli ładowanie się powiodło
+func (l *loader) err() error {
+	if len(l.errors) == 0 {
+		return nil
+	}
+	return LoadErrors(l.errors)
+}
+
+// errorMarks zawiera liczbę błędów zebranych przed ładowaniem każdego pola struktury
+// oraz - na ostatniej pozycji - po załadowaniu ostatniego pola
+type errorMarks []int
+
+// failed sprawdza czy ładowanie pola o podanym indeksie zakończyło się błędem
+func (m errorMarks) failed(index int) bool {
+	return m[index+1] > m[index]
+}
+
+// enter oznacza typ struktury jako przetwarzany; leave zdejmuje to oznaczenie
+func (l *loader) enter(structType reflect.Type) {
+	if l.walking == nil {
+		l.walking = make(map[reflect.Type]bool)
+	}
+	l.walking[structType] = true
+}
+
+// leave kończy przetwarzanie typu struktury oznaczonego przez enter
+func (l *loader) leave(structType reflect.Type) {
+	delete(l.walking, structType)
+}
+
+// recursiveField zwraca błąd dla pola zagnieżdżonej struktury, której typ jest już
+// przetwarzany wyżej (np. Fallback *Node w strukturze Node). Takiej struktury nie da się
+// załadować ani opisać, bo każdy poziom wymagałby kolejnego.
+func (l *loader) recursiveField(fieldType reflect.StructField, fieldPath string) error {
+	nestedType := fieldType.Type
+	if nestedType.Kind() == reflect.Ptr {
+		nestedType = nestedType.Elem()
+	}
+	if !l.walking[nestedType] {
+		return nil
+	}
+	return &UnsupportedFieldTypeError{
+		FieldName: fieldType.Name,
+		FieldPath: fieldPath,
+		FieldType: fieldType.Type.String(),
+	}
+}
+
+// loadStruct ładuje wartości do pól struktury, korzystając ze źródła skonfigurowanego w opcjach.
+// Zakres określa ścieżkę struktury, prefiks nazw zmiennych i sposób wyprowadzania nazw.
+// Zwraca informację, czy którekolwiek pole (również w zagnieżdżonych strukturach)
+// otrzymało wartość ze źródła - wartości domyślne się nie liczą, aby same nie powodowały
+// alokacji struktury wskaźnikowej. Błędy pól są zapisywane w loaderze.
+func (l *loader) loadStruct(structValue reflect.Value, sc scope) bool {
 	structType := structValue.Type()
+	provided := false
+	l.enter(structType)
+	defer l.leave(structType)
+
+	// Warunki required_if, excluded_with itp. oraz grupy pól są sprawdzane
+	// po załadowaniu wszystkich pól
+	var pending []conditional
+	var groups []*fieldGroup
+	set := make([]bool, structValue.NumField())     // Pole otrzymało wartość (również domyślną)
+	sourced := make([]bool, structValue.NumField()) // Pole otrzymało wartość ze źródła, a nie z wartości domyślnej
+	marks := make(errorMarks, structValue.NumField()+1)
 
 	// Iteracja przez wszystkie pola struktury
 	for i := 0; i < structValue.NumField(); i++ {
+		marks[i] = len(l.errors)
 		field := structValue.Field(i)
 		fieldType := structType.Field(i)
 
@@ -28,77 +126,359 @@ func LoadStruct(structValue reflect.Value) error {
 			continue
 		}
 
+		// Pełna ścieżka pola używana w komunikatach błędów
+		fieldPath := joinPath(sc.path, fieldType.Name)
+
 		// Pobierz tag konfiguracji dla pola
 		tag := fieldType.Tag.Get(Tag)
 
 		// Parsowanie tagu do mapy klucz-wartość
-		tagMap := parseTag(tag)
+		tagMap, err := parseTag(tag)
+		if err != nil {
+			l.fail(tagError(err, fieldType.Name, fieldPath))
+			continue
+		}
+
+		// Sposób wyprowadzania nazw może zostać nadpisany dla pola i jego potomków
+		naming := sc.naming
+		if value, ok := tagMap[NamingKey]; ok {
+			if naming, err = parseNaming(value); err != nil {
+				l.fail(tagError(newTagSyntaxError(tag, "%v", err), fieldType.Name, fieldPath))
+				continue
+			}
+		}
+
+		// Zagnieżdżone struktury (również przez wskaźnik) przetwarzamy rekurencyjnie
+		// tym samym loaderem, aby korzystały z tego samego źródła wartości.
+		if isNestedStruct(field.Type()) || isNestedStructPtr(field.Type()) {
+			if err := l.recursiveField(fieldType, fieldPath); err != nil {
+				l.fail(err)
+				continue
+			}
+			nested := scope{
+				path:   fieldPath,
+				prefix: sc.prefix + l.nestedPrefix(fieldType, tagMap, naming),
+				naming: naming,
+			}
+			if l.loadNested(field, nested) {
+				provided = true
+			}
+			continue
+		}
 
 		// Ustalenie nazwy zmiennej środowiskowej
-		envName, ok := tagMap[EnvKey]
-		if !ok {
-			// Jeśli nie określono nazwy zmiennej, użyj nazwy pola w górnym rejestrze
-			envName = strings.ToUpper(fieldType.Name)
+		envName := fieldEnvName(fieldType, tagMap, naming, sc.prefix)
+
+		// Reguły walidacji są przygotowywane zawsze, aby błędy w tagu były zgłaszane
+		// niezależnie od tego, czy pole otrzyma wartość
+		rules, err := parseRules(field.Type(), tagMap)
+		if err != nil {
+			l.fail(tagError(newTagSyntaxError(tag, "%v", err), fieldType.Name, fieldPath))
+			continue
+		}
+
+		// Warunki wymagania odwołują się do innych pól struktury, więc są sprawdzane
+		// dopiero po jej załadowaniu (zob. checkConditions)
+		conditions, err := parseConditions(structType, tagMap)
+		if err != nil {
+			l.fail(tagError(newTagSyntaxError(tag, "%v", err), fieldType.Name, fieldPath))
+			continue
+		}
+		if len(conditions) > 0 {
+			pending = append(pending, conditional{
+				index:      i,
+				name:       fieldType.Name,
+				path:       fieldPath,
+				envName:    envName,
+				conditions: conditions,
+			})
+		}
+		aliases, deprecated, err := envAliases(tagMap, sc.prefix)
+		if err != nil {
+			l.fail(tagError(newTagSyntaxError(tag, "%v", err), fieldType.Name, fieldPath))
+			continue
+		}
+		if groups, err = addGroupMember(groups, tagMap, i, envName); err != nil {
+			l.fail(tagError(newTagSyntaxError(tag, "%v", err), fieldType.Name, fieldPath))
+			continue
+		}
+		secret := tagBool(tagMap, SecretKey, false) || isSecretType(field.Type())
+
+		// W trybie allowEmpty pusta, ale ustawiona zmienna jest traktowana jako wartość,
+		// w przeciwnym razie pusty ciąg oznacza brak wartości
+		allowEmpty := tagBool(tagMap, AllowEmptyKey, l.opts.allowEmpty)
+
+		// Pobierz wartość ze źródła konfiguracji - ze zmiennej głównej, jej aliasów
+		// lub zmiennych o przestarzałych nazwach, a także z plików wskazanych zmiennymi
+		// z sufiksem _FILE. Dalsze komunikaty i raport wskazują zmienną, z której
+		// pochodzi wartość.
+		v, err := l.lookupValue(envName, aliases, deprecated, fieldPath, allowEmpty, tagBool(tagMap, FileKey, l.opts.files))
+		if err != nil {
+			l.fail(fileError(err, fieldType.Name, fieldPath))
+			continue
+		}
+		envName, envValue, source, found := v.envName, v.value, v.source, v.found
+
+		// Zmienna ustawiona na pusty ciąg jest błędem, jeśli pole tego zabrania
+		if found && envValue == "" && tagBool(tagMap, NotEmptyKey, false) {
+			l.fail(&EmptyValueError{
+				FieldName: fieldType.Name,
+				FieldPath: fieldPath,
+				EnvName:   envName,
+			})
+			continue
 		}
 
-		// Pobierz wartość z zmiennej środowiskowej
-		envValue := os.Getenv(envName)
+		// Pochodzenie wartości pola trafia do raportu (zob. WithReport)
+		report := FieldReport{
+			Path:     fieldPath,
+			EnvName:  envName,
+			Source:   source,
+			Required: tagBool(tagMap, RequiredKey, false),
+		}
 
 		// Jeśli zmienna środowiskowa nie jest ustawiona, użyj wartości domyślnej
-		if envValue == "" {
+		fromSource := true
+		if !found || (envValue == "" && !allowEmpty) {
 			defaultValue, ok := tagMap[DefaultKey]
-			if ok {
-				envValue = defaultValue
-			} else {
+			if !ok {
 				// Sprawdź czy pole jest wymagane
-				if required, ok := tagMap[RequiredKey]; ok && required == "true" {
-					return &RequiredFieldError{
+				if tagBool(tagMap, RequiredKey, false) {
+					l.fail(&RequiredFieldError{
 						FieldName: fieldType.Name,
+						FieldPath: fieldPath,
 						EnvName:   envName,
-					}
+					})
+					continue
 				}
-				// Jeśli nie ma wartości domyślnej i pole nie jest wymagane,
-				// sprawdź czy to struktura - jeśli tak, przetwarzaj ją rekurencyjnie
-				if field.Kind() == reflect.Struct {
-					if err := LoadStruct(field); err != nil {
-						return err
-					}
+				// Pole bez wartości pozostaje niezmienione (wskaźniki pozostają nil)
+				report.Source = SourceZero
+				l.record(report)
+				continue
+			}
+			envValue = defaultValue
+			fromSource = false
+			report.Source = SourceDefault
+		}
+
+		// Odwołania ${VAR} są rozwijane na podstawie tego samego źródła wartości,
+		// o ile nie zrobił tego już parser pliku .env
+		if tagBool(tagMap, ExpandKey, l.opts.expand) && (!fromSource || !v.expanded) {
+			start := ""
+			if fromSource {
+				start = envName
+			}
+			if envValue, err = expandValue(l.opts.lookuper, start, envValue); err != nil {
+				// Błąd pola z sekretem nie może ujawnić jego wartości
+				if secret {
+					err = redactInterpolationError(err)
 				}
+				l.fail(interpolationError(err, fieldType.Name, fieldPath, envName))
 				continue
 			}
 		}
 
 		// Ustaw wartość pola na podstawie wartości zmiennej środowiskowej
-		if err := setFieldValue(field, envValue, fieldType.Name); err != nil {
-			return err
+		if err := setValue(field, envValue, fieldType.Name, tagMap); err != nil {
+			// Błąd pola z sekretem nie może ujawnić jego wartości
+			if secret {
+				err = redactParseError(err)
+			}
+			l.fail(annotateError(err, sc.path, envName))
+			continue
+		}
+
+		// Walidacja ustawionej wartości regułami z tagu
+		for _, err := range checkRules(field, fieldType.Name, rules, tagMap, secret) {
+			l.fail(annotateError(err, sc.path, envName))
+		}
+		l.record(report)
+		set[i] = true
+		sourced[i] = fromSource
+		if fromSource {
+			provided = true
 		}
 	}
+	marks[structValue.NumField()] = len(l.errors)
 
-	return nil
+	if len(pending) > 0 {
+		l.checkConditions(structValue, sc, pending, set, sourced, marks)
+	}
+	if len(groups) > 0 {
+		l.checkGroups(sc, groups, sourced, marks)
+	}
+	return provided
+}
+
+// fieldEnvName zwraca główną nazwę zmiennej, z której ładowane jest pole: pierwszą nazwę
+// z klucza env lub - jeśli jej nie określono - nazwę wyprowadzoną z nazwy pola,
+// poprzedzoną prefiksem. Pozostałe nazwy z klucza env zwraca envAliases.
+func fieldEnvName(fieldType reflect.StructField, tagMap map[string]string, naming Naming, prefix string) string {
+	envName, ok := tagMap[EnvKey]
+	if !ok {
+		envName = naming.envName(fieldType.Name)
+	}
+	if name, _, found := strings.Cut(envName, "|"); found {
+		envName = strings.TrimSpace(name)
+	}
+	return prefix + envName
+}
+
+// nestedPrefix zwraca prefiks, który zagnieżdżona struktura dodaje do nazw swoich zmiennych.
+// Jawny prefiks z tagu ma pierwszeństwo. Przy włączonej opcji WithNestedNames prefiksem
+// jest nazwa pola wyprowadzona tak jak nazwa zmiennej (np. "DATABASE_"),
+// z wyjątkiem struktur osadzonych, które nie tworzą własnego poziomu nazw.
+func (l *loader) nestedPrefix(fieldType reflect.StructField, tagMap map[string]string, naming Naming) string {
+	if prefix, ok := tagMap[PrefixKey]; ok {
+		return prefix
+	}
+	if l.opts.nestedNames && !fieldType.Anonymous {
+		return naming.envName(fieldType.Name) + "_"
+	}
+	return ""
 }
 
-// parseTag parsuje tag struktury w formacie "klucz1=wartość1,klucz2=wartość2"
-// i zwraca mapę par klucz-wartość.
-func parseTag(tag string) map[string]string {
-	result := make(map[string]string)
-	// Podział tagu na części oddzielone przecinkami
-	parts := strings.Split(tag, ",")
+// loadNested ładuje zagnieżdżoną strukturę lub wskaźnik do struktury w podanym zakresie.
+// Wskaźnik równy nil jest alokowany tylko wtedy, gdy którekolwiek z pól potomnych
+// otrzymało wartość ze źródła - w przeciwnym razie pozostaje nil (również gdy jej pola
+// mają wartości domyślne), błędy brakujących wartości w jego polach są pomijane
+// (zob. dropUnconfigured), a raport wskazuje, że pola nie otrzymały wartości.
+func (l *loader) loadNested(field reflect.Value, sc scope) bool {
+	if field.Kind() != reflect.Ptr {
+		return l.loadValidated(field, sc, false)
+	}
+
+	// Istniejąca struktura jest uzupełniana w miejscu
+	if !field.IsNil() {
+		return l.loadValidated(field.Elem(), sc, false)
+	}
+
+	nested := reflect.New(field.Type().Elem())
+	before, reported := len(l.errors), l.reported()
+	provided := l.loadValidated(nested.Elem(), sc, true)
+	if provided {
+		field.Set(nested)
+	} else {
+		l.dropUnconfigured(before)
+		l.discardReport(reported)
+	}
+	return provided
+}
 
-	// Przetwarzanie każdej części jako pary klucz=wartość
-	for _, part := range parts {
-		// Podział na klucz i wartość przy pierwszym znaku "="
-		kv := strings.SplitN(part, "=", 2)
-		if len(kv) == 2 {
-			// Dodanie pary do mapy wynikowej, usuwając białe znaki
-			result[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+// dropUnconfigured usuwa błędy zebrane od pozycji from podczas ładowania struktury
+// wskaźnikowej, która pozostaje nil, jeśli wszystkie dotyczą jedynie brakujących wartości
+// (wymagane pola, wymagania warunkowe, grupy pól). Struktura, dla której nie podano
+// żadnej wartości, jest nieskonfigurowana, a nie niekompletna. Inne błędy (np. parsowania
+// ustawionej zmiennej) oznaczają, że strukturę próbowano skonfigurować - wtedy zachowywane
+// są wszystkie błędy.
+func (l *loader) dropUnconfigured(from int) {
+	for _, err := range l.errors[from:] {
+		switch err.(type) {
+		case *RequiredFieldError, *GroupError:
+		default:
+			return
 		}
 	}
+	l.errors = l.errors[:from]
+}
 
-	return result
+// joinPath dokleja nazwę pola do kropkowanej ścieżki struktury nadrzędnej
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// tagError uzupełnia błąd składni tagu o nazwę i pełną ścieżkę pola
+func tagError(err error, fieldName string, fieldPath string) error {
+	var tagErr *TagSyntaxError
+	if errors.As(err, &tagErr) {
+		tagErr.FieldName = fieldName
+		tagErr.FieldPath = fieldPath
+	}
+	return err
+}
+
+// annotateError uzupełnia błąd zwrócony przez setValue lub checkRules o pełną ścieżkę pola i nazwę zmiennej.
+// Błędy zawierają nazwę pola względem struktury (np. "Ports[1]"), więc ścieżka
+// jest budowana od ścieżki struktury nadrzędnej.
+func annotateError(err error, structPath string, envName string) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.FieldPath = joinPath(structPath, parseErr.FieldName)
+		parseErr.EnvName = envName
+	}
+	var typeErr *UnsupportedFieldTypeError
+	if errors.As(err, &typeErr) {
+		typeErr.FieldPath = joinPath(structPath, typeErr.FieldName)
+		typeErr.EnvName = envName
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		validationErr.FieldPath = joinPath(structPath, validationErr.FieldName)
+		validationErr.EnvName = envName
+	}
+	return err
+}
+
+// tagBool zwraca wartość logiczną klucza tagu lub wartość fallback,
+// jeśli klucz nie występuje w tagu albo nie jest poprawną wartością logiczną.
+func tagBool(tagMap map[string]string, key string, fallback bool) bool {
+	value, ok := tagMap[key]
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+// isNestedStruct sprawdza czy typ jest zagnieżdżoną strukturą konfiguracyjną,
+// której pola należy przetworzyć rekurencyjnie (a nie typem wartości, jak time.Time
+// lub struktura implementująca Decoder czy encoding.TextUnmarshaler).
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) && !implementsDecoder(t)
+}
+
+// isNestedStructPtr sprawdza czy typ jest wskaźnikiem do zagnieżdżonej struktury konfiguracyjnej
+func isNestedStructPtr(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && isNestedStruct(t.Elem())
+}
+
+// setValue ustawia wartość pola na podstawie wartości tekstowej, uwzględniając ustawienia z tagu.
+// Kolekcje (slice, tablice, mapy) są dzielone na elementy, wskaźniki są alokowane,
+// a wartości skalarne trafiają do setFieldValue.
+func setValue(field reflect.Value, value string, fieldName string, tagMap map[string]string) error {
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		// Własne typy kolekcji (np. net.IP) mogą same parsować swoją wartość
+		if handled, err := decodeValue(field, value, fieldName); handled {
+			return err
+		}
+		return setListValue(field, value, fieldName, tagMap)
+	case reflect.Map:
+		if handled, err := decodeValue(field, value, fieldName); handled {
+			return err
+		}
+		return setMapValue(field, value, fieldName, tagMap)
+	case reflect.Ptr:
+		// Wskaźnik jest alokowany dopiero, gdy istnieje wartość do ustawienia
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), value, fieldName, tagMap); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	return setFieldValue(field, value, fieldName)
 }
 
 // setFieldValue ustawia wartość pola struktury na podstawie wartości tekstowej.
-// Funkcja obsługuje różne typy danych, w tym string, int, uint, float, bool, time.Time i time.Duration.
+// Funkcja obsługuje różne typy danych, w tym string, int, uint, float, bool, time.Time i time.Duration,
+// a także typy implementujące Decoder, encoding.TextUnmarshaler lub encoding.BinaryUnmarshaler.
 // Dla nieobsługiwanych typów zwraca błąd.
 func setFieldValue(field reflect.Value, value string, fieldName string) error {
 
@@ -132,6 +512,12 @@ func setFieldValue(field reflect.Value, value string, fieldName string) error {
 		return nil
 	}
 
+	// Typy implementujące Decoder, encoding.TextUnmarshaler lub encoding.BinaryUnmarshaler
+	// same parsują swoją wartość
+	if handled, err := decodeValue(field, value, fieldName); handled {
+		return err
+	}
+
 	// Obsługa standardowych typów Go na podstawie rodzaju pola
 	switch field.Kind() {
 	case reflect.String:
@@ -185,12 +571,12 @@ func setFieldValue(field reflect.Value, value string, fieldName string) error {
 			}
 		}
 		field.SetBool(boolValue)
-	case reflect.Struct:
-		// Rekurencyjne przetwarzanie zagnieżdżonych struktur
-		return LoadStruct(field)
 	default:
 		// Zwróć błąd dla nieobsługiwanych typów
-		return fmt.Errorf("%w: %s", ErrUnsupportedFieldType, field.Kind().String())
+		return &UnsupportedFieldTypeError{
+			FieldName: fieldName,
+			FieldType: field.Type().String(),
+		}
 	}
 	return nil
 }
@@ -8,69 +8,6 @@ import (
 	"time"
 )
 
-// TestParseTag sprawdza funkcję parseTag
-func TestParseTag(t *testing.T) {
-	tests := []struct {
-		name     string
-		tag      string
-		expected map[string]string
-	}{
-		{
-			name:     "Empty tag",
-			tag:      "",
-			expected: map[string]string{},
-		},
-		{
-			name: "Single key-value pair",
-			tag:  "env=TEST_VAR",
-			expected: map[string]string{
-				"env": "TEST_VAR",
-			},
-		},
-		{
-			name: "Multiple key-value pairs",
-			tag:  "env=TEST_VAR,default=default value,required=true",
-			expected: map[string]string{
-				"env":      "TEST_VAR",
-				"default":  "default value",
-				"required": "true",
-			},
-		},
-		{
-			name: "Whitespace handling",
-			tag:  " env = TEST_VAR , default = default value ",
-			expected: map[string]string{
-				"env":     "TEST_VAR",
-				"default": "default value",
-			},
-		},
-		{
-			name:     "Invalid format (no value)",
-			tag:      "env",
-			expected: map[string]string{},
-		},
-		{
-			name: "Mixed valid and invalid",
-			tag:  "env=TEST_VAR,invalid,default=value",
-			expected: map[string]string{
-				"env":     "TEST_VAR",
-				"default": "value",
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(
-			tt.name, func(t *testing.T) {
-				result := parseTag(tt.tag)
-				if !reflect.DeepEqual(result, tt.expected) {
-					t.Errorf("parseTag() = %v, want %v", result, tt.expected)
-				}
-			},
-		)
-	}
-}
-
 // TestSetFieldValue sprawdza funkcję setFieldValue
 func TestSetFieldValue(t *testing.T) {
 	// Test dla string
@@ -613,3 +550,435 @@ func TestLoadStruct(t *testing.T) {
 		},
 	)
 }
+
+// TestLoadStruct_EmptyValues sprawdza rozróżnienie zmiennej nieustawionej od ustawionej na pusty ciąg
+func TestLoadStruct_EmptyValues(t *testing.T) {
+	t.Parallel()
+
+	source := WithLookuper(MapLookuper(map[string]string{"EMPTY_VALUE": ""}))
+
+	t.Run(
+		"Empty value falls back to default", func(t *testing.T) {
+			type Config struct {
+				Value string `envconfig:"env=EMPTY_VALUE,default=fallback"`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Value != "fallback" {
+				t.Errorf("LoadStruct() Value = %v, want %v", cfg.Value, "fallback")
+			}
+		},
+	)
+
+	t.Run(
+		"Global allowEmpty option", func(t *testing.T) {
+			type Config struct {
+				Value   string `envconfig:"env=EMPTY_VALUE,default=fallback"`
+				Missing string `envconfig:"env=MISSING_VALUE,default=fallback"`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source, WithAllowEmpty()); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Value != "" {
+				t.Errorf("LoadStruct() Value = %v, want empty string", cfg.Value)
+			}
+			if cfg.Missing != "fallback" {
+				t.Errorf("LoadStruct() Missing = %v, want %v", cfg.Missing, "fallback")
+			}
+		},
+	)
+
+	t.Run(
+		"Per-field allowEmpty tag", func(t *testing.T) {
+			type Config struct {
+				Allowed    string `envconfig:"env=EMPTY_VALUE,default=fallback,allowEmpty=true"`
+				Disallowed string `envconfig:"env=EMPTY_VALUE,default=fallback,allowEmpty=false"`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source, WithAllowEmpty()); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Allowed != "" {
+				t.Errorf("LoadStruct() Allowed = %v, want empty string", cfg.Allowed)
+			}
+			if cfg.Disallowed != "fallback" {
+				t.Errorf("LoadStruct() Disallowed = %v, want %v", cfg.Disallowed, "fallback")
+			}
+		},
+	)
+
+	t.Run(
+		"Required field set to empty with allowEmpty", func(t *testing.T) {
+			type Config struct {
+				Value string `envconfig:"env=EMPTY_VALUE,required=true,allowEmpty=true"`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Errorf("LoadStruct() error = %v, want nil", err)
+			}
+		},
+	)
+
+	t.Run(
+		"NotEmpty rejects empty value", func(t *testing.T) {
+			type Config struct {
+				Value string `envconfig:"env=EMPTY_VALUE,default=fallback,notEmpty=true"`
+			}
+
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+
+			var emptyErr *EmptyValueError
+			if !errors.As(err, &emptyErr) {
+				t.Fatalf("LoadStruct() error type = %T, want *EmptyValueError", err)
+			}
+			if emptyErr.EnvName != "EMPTY_VALUE" {
+				t.Errorf("EmptyValueError.EnvName = %v, want %v", emptyErr.EnvName, "EMPTY_VALUE")
+			}
+			if !errors.Is(err, ErrEmptyValue) {
+				t.Errorf("LoadStruct() error = %v, want %v", err, ErrEmptyValue)
+			}
+		},
+	)
+}
+
+// TestLoadStruct_Pointers sprawdza pola wskaźnikowe pozostające nil, gdy brak wartości
+func TestLoadStruct_Pointers(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Host string `envconfig:"env=PTR_DB_HOST"`
+		Port *int   `envconfig:"env=PTR_DB_PORT"`
+	}
+
+	type Config struct {
+		Port     *int           `envconfig:"env=PTR_PORT"`
+		Name     *string        `envconfig:"env=PTR_NAME,default=app"`
+		Missing  *string        `envconfig:"env=PTR_MISSING"`
+		Timeout  *time.Duration `envconfig:"env=PTR_TIMEOUT"`
+		Hosts    *[]string      `envconfig:"env=PTR_HOSTS"`
+		Database *Database
+		Cache    *Database
+	}
+
+	t.Run(
+		"Values provided", func(t *testing.T) {
+			source := WithLookuper(MapLookuper(map[string]string{
+				"PTR_PORT":    "0",
+				"PTR_TIMEOUT": "5s",
+				"PTR_HOSTS":   "a,b",
+				"PTR_DB_PORT": "5432",
+			}))
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if cfg.Port == nil || *cfg.Port != 0 {
+				t.Errorf("Port = %v, want pointer to 0", cfg.Port)
+			}
+			if cfg.Name == nil || *cfg.Name != "app" {
+				t.Errorf("Name = %v, want pointer to %v", cfg.Name, "app")
+			}
+			if cfg.Missing != nil {
+				t.Errorf("Missing = %v, want nil", *cfg.Missing)
+			}
+			if cfg.Timeout == nil || *cfg.Timeout != 5*time.Second {
+				t.Errorf("Timeout = %v, want pointer to %v", cfg.Timeout, 5*time.Second)
+			}
+			if cfg.Hosts == nil || !reflect.DeepEqual(*cfg.Hosts, []string{"a", "b"}) {
+				t.Errorf("Hosts = %v, want pointer to [a b]", cfg.Hosts)
+			}
+			if cfg.Database == nil || cfg.Database.Port == nil || *cfg.Database.Port != 5432 {
+				t.Errorf("Database = %+v, want allocated struct with Port 5432", cfg.Database)
+			}
+			// Cache używa tych samych zmiennych, więc również zostaje zaalokowany
+			if cfg.Cache == nil {
+				t.Errorf("Cache = nil, want allocated struct")
+			}
+		},
+	)
+
+	t.Run(
+		"Nothing provided", func(t *testing.T) {
+			type Config struct {
+				Port     *int `envconfig:"env=PTR_PORT"`
+				Database *Database
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(MapLookuper(nil))); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Port != nil {
+				t.Errorf("Port = %v, want nil", *cfg.Port)
+			}
+			if cfg.Database != nil {
+				t.Errorf("Database = %+v, want nil", cfg.Database)
+			}
+		},
+	)
+
+	t.Run(
+		"Defaults do not allocate struct", func(t *testing.T) {
+			type DatabaseConfig struct {
+				Host string `envconfig:"env=DB_HOST,default=localhost"`
+				Port int    `envconfig:"env=DB_PORT,required"`
+			}
+			type Config struct {
+				Primary DatabaseConfig  `envconfig:"prefix=PTR_PRIMARY_"`
+				Replica *DatabaseConfig `envconfig:"prefix=PTR_REPLICA_"`
+			}
+
+			var cfg Config
+			var report Report
+			source := MapLookuper(map[string]string{"PTR_PRIMARY_DB_PORT": "5432"})
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(source), WithReport(&report)); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Primary.Host != "localhost" || cfg.Primary.Port != 5432 {
+				t.Errorf("Primary = %+v, want default host and port 5432", cfg.Primary)
+			}
+			if cfg.Replica != nil {
+				t.Errorf("Replica = %+v, want nil", cfg.Replica)
+			}
+			if field, ok := report.Field("Replica.Host"); !ok || field.Source != SourceZero {
+				t.Errorf("Report.Field(Replica.Host) = %+v, want source %s", field, SourceZero)
+			}
+
+			// Zmienna repliki alokuje strukturę i uzupełnia pozostałe pola wartościami domyślnymi
+			source = MapLookuper(map[string]string{"PTR_PRIMARY_DB_PORT": "5432", "PTR_REPLICA_DB_PORT": "5433"})
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(source)); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Replica == nil || cfg.Replica.Host != "localhost" || cfg.Replica.Port != 5433 {
+				t.Errorf("Replica = %+v, want default host and port 5433", cfg.Replica)
+			}
+		},
+	)
+
+	t.Run(
+		"Unconfigured struct with required fields", func(t *testing.T) {
+			type DB struct {
+				Host string `envconfig:"env=PTR_REQ_HOST,required"`
+				Port int    `envconfig:"env=PTR_REQ_PORT,required_with=Host"`
+				User string `envconfig:"env=PTR_REQ_USER,group=auth,atleastone"`
+			}
+			type Config struct {
+				DB *DB `envconfig:"prefix=PTR_"`
+			}
+
+			var cfg Config
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(MapLookuper(nil))); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.DB != nil {
+				t.Errorf("DB = %+v, want nil", cfg.DB)
+			}
+
+			// Struktura, dla której podano błędną wartość, zgłasza wszystkie błędy
+			source := WithLookuper(MapLookuper(map[string]string{"PTR_PTR_REQ_PORT": "x"}))
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+			var loadErrs LoadErrors
+			if !errors.As(err, &loadErrs) || len(loadErrs) != 3 {
+				t.Fatalf("LoadStruct() error = %v, want 3 errors", err)
+			}
+			var reqErr *RequiredFieldError
+			if !errors.As(err, &reqErr) || reqErr.FieldPath != "DB.Host" {
+				t.Errorf("LoadStruct() error = %v, want RequiredFieldError for DB.Host", err)
+			}
+		},
+	)
+
+	t.Run(
+		"Existing struct pointer is filled in place", func(t *testing.T) {
+			existing := &Database{Host: "preset"}
+			cfg := Config{Database: existing}
+			source := WithLookuper(MapLookuper(map[string]string{"PTR_DB_PORT": "1"}))
+
+			if err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source); err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Database != existing || cfg.Database.Host != "preset" {
+				t.Errorf("Database = %+v, want existing struct", cfg.Database)
+			}
+		},
+	)
+
+	t.Run(
+		"Recursive struct type", func(t *testing.T) {
+			type Node struct {
+				Name     string `envconfig:"env=PTR_NODE_NAME"`
+				Fallback *Node
+			}
+			type Config struct {
+				Primary Node
+			}
+
+			var cfg Config
+			source := WithLookuper(MapLookuper(map[string]string{"PTR_NODE_NAME": "a"}))
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+
+			var typeErr *UnsupportedFieldTypeError
+			if !errors.As(err, &typeErr) {
+				t.Fatalf("LoadStruct() error type = %T, want *UnsupportedFieldTypeError", err)
+			}
+			if typeErr.FieldPath != "Primary.Fallback" || typeErr.FieldType != "*envconfig.Node" {
+				t.Errorf("UnsupportedFieldTypeError = %+v", typeErr)
+			}
+			if cfg.Primary.Name != "a" || cfg.Primary.Fallback != nil {
+				t.Errorf("Primary = %+v, want Name a and nil Fallback", cfg.Primary)
+			}
+		},
+	)
+
+	t.Run(
+		"Invalid pointer value", func(t *testing.T) {
+			type Config struct {
+				Port *int `envconfig:"env=PTR_PORT"`
+			}
+
+			var cfg Config
+			err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(MapLookuper(map[string]string{"PTR_PORT": "x"})))
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("LoadStruct() error type = %T, want *ParseError", err)
+			}
+			if cfg.Port != nil {
+				t.Errorf("Port = %v, want nil", *cfg.Port)
+			}
+		},
+	)
+}
+
+// TestLoadStruct_AggregatesErrors sprawdza zbieranie wszystkich błędów pól
+func TestLoadStruct_AggregatesErrors(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Host string `envconfig:"env=AGG_DB_HOST,required=true"`
+		Port int    `envconfig:"env=AGG_DB_PORT"`
+	}
+
+	type Config struct {
+		Name     string `envconfig:"env=AGG_NAME,required=true"`
+		Port     int    `envconfig:"env=AGG_PORT"`
+		Token    string `envconfig:"env=AGG_TOKEN,notEmpty=true"`
+		Valid    string `envconfig:"env=AGG_VALID"`
+		Database Database
+	}
+
+	source := WithLookuper(MapLookuper(map[string]string{
+		"AGG_PORT":    "abc",
+		"AGG_TOKEN":   "",
+		"AGG_VALID":   "ok",
+		"AGG_DB_PORT": "x",
+	}))
+
+	var cfg Config
+	err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) {
+		t.Fatalf("LoadStruct() error type = %T, want LoadErrors", err)
+	}
+	if len(loadErrs) != 5 {
+		t.Fatalf("len(LoadErrors) = %d, want 5: %v", len(loadErrs), err)
+	}
+
+	var reqErr *RequiredFieldError
+	if !errors.As(err, &reqErr) {
+		t.Errorf("LoadStruct() error does not contain *RequiredFieldError")
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("LoadStruct() error does not contain *ParseError")
+	}
+	if !errors.Is(err, ErrEmptyValue) {
+		t.Errorf("LoadStruct() error does not contain ErrEmptyValue")
+	}
+
+	// Poprawne pola są ładowane mimo błędów w innych polach
+	if cfg.Valid != "ok" {
+		t.Errorf("Valid = %v, want %v", cfg.Valid, "ok")
+	}
+}
+
+// TestLoadStruct_FieldPaths sprawdza pełne ścieżki pól w błędach zagnieżdżonych struktur
+func TestLoadStruct_FieldPaths(t *testing.T) {
+	t.Parallel()
+
+	type Endpoint struct {
+		Host  string `envconfig:"env=HOST,required=true"`
+		Ports []int  `envconfig:"env=PORTS"`
+	}
+
+	type Database struct {
+		Primary Endpoint `envconfig:"prefix=PRIMARY_"`
+	}
+
+	type Config struct {
+		Server   Endpoint  `envconfig:"prefix=SERVER_"`
+		Database *Database `envconfig:"prefix=DB_"`
+		Channel  chan int  `envconfig:"env=CHANNEL"`
+	}
+
+	source := WithLookuper(MapLookuper(map[string]string{
+		"SERVER_PORTS":     "80,abc",
+		"DB_PRIMARY_PORTS": "5432",
+		"CHANNEL":          "x",
+	}))
+
+	var cfg Config
+	err := LoadStruct(reflect.ValueOf(&cfg).Elem(), source)
+
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) {
+		t.Fatalf("LoadStruct() error type = %T, want LoadErrors", err)
+	}
+
+	var requiredPaths []string
+	var parseErr *ParseError
+	for _, fieldErr := range loadErrs {
+		var reqErr *RequiredFieldError
+		if errors.As(fieldErr, &reqErr) {
+			requiredPaths = append(requiredPaths, reqErr.FieldPath+"="+reqErr.EnvName)
+		}
+		errors.As(fieldErr, &parseErr)
+	}
+
+	expected := []string{"Server.Host=SERVER_HOST", "Database.Primary.Host=DB_PRIMARY_HOST"}
+	if !reflect.DeepEqual(requiredPaths, expected) {
+		t.Errorf("RequiredFieldError paths = %v, want %v", requiredPaths, expected)
+	}
+
+	if parseErr == nil {
+		t.Fatalf("LoadStruct() error does not contain *ParseError")
+	}
+	if parseErr.FieldPath != "Server.Ports[1]" {
+		t.Errorf("ParseError.FieldPath = %v, want %v", parseErr.FieldPath, "Server.Ports[1]")
+	}
+	if parseErr.EnvName != "SERVER_PORTS" {
+		t.Errorf("ParseError.EnvName = %v, want %v", parseErr.EnvName, "SERVER_PORTS")
+	}
+
+	if !errors.Is(err, ErrUnsupportedFieldType) {
+		t.Errorf("LoadStruct() error does not contain ErrUnsupportedFieldType")
+	}
+	var typeErr *UnsupportedFieldTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("LoadStruct() error does not contain *UnsupportedFieldTypeError")
+	}
+	if typeErr.FieldPath != "Channel" || typeErr.EnvName != "CHANNEL" || typeErr.FieldType != "chan int" {
+		t.Errorf("UnsupportedFieldTypeError = %+v, want Channel/CHANNEL/chan int", typeErr)
+	}
+}
@@ -0,0 +1,59 @@
+package envconfig
+
+// Nazwy źródeł wartości używane w raporcie pochodzenia wartości
+const (
+	SourceEnv     = "env"     // Wartość ze zmiennej środowiskowej (lub źródła bez własnej nazwy)
+	SourceDefault = "default" // Wartość domyślna z tagu
+	SourceZero    = "zero"    // Brak wartości - pole zachowało swoją dotychczasową (zwykle zerową) wartość
+)
+
+// Report opisuje pochodzenie wartości wszystkich pól załadowanej konfiguracji.
+// Wypełniany przez LoadWith z opcją WithReport, np. aby zalogować konfigurację przy starcie.
+type Report struct {
+	Fields []FieldReport // Pola w kolejności występowania w strukturze
+}
+
+// FieldReport opisuje pochodzenie wartości pojedynczego pola
+type FieldReport struct {
+	Path     string // Kropkowana ścieżka pola, np. "Database.Host"
+	EnvName  string // Nazwa zmiennej, z której ładowane jest pole
+	Source   string // SourceEnv, SourceDefault, SourceZero lub nazwa źródła (np. ścieżka pliku)
+	Required bool   // Czy pole jest wymagane
+}
+
+// Field zwraca opis pola o podanej ścieżce
+func (r *Report) Field(path string) (FieldReport, bool) {
+	for _, field := range r.Fields {
+		if field.Path == path {
+			return field, true
+		}
+	}
+	return FieldReport{}, false
+}
+
+// record dopisuje pole do raportu, jeśli raport został zażądany
+func (l *loader) record(field FieldReport) {
+	if l.opts.report != nil {
+		l.opts.report.Fields = append(l.opts.report.Fields, field)
+	}
+}
+
+// reported zwraca liczbę pól zapisanych dotąd w raporcie
+func (l *loader) reported() int {
+	if l.opts.report == nil {
+		return 0
+	}
+	return len(l.opts.report.Fields)
+}
+
+// discardReport oznacza pola zapisane w raporcie od pozycji from jako pola bez wartości.
+// Używane dla struktury wskaźnikowej, która pozostaje nil, więc wartości domyślne
+// jej pól nie zostały ostatecznie ustawione.
+func (l *loader) discardReport(from int) {
+	if l.opts.report == nil {
+		return
+	}
+	for i := from; i < len(l.opts.report.Fields); i++ {
+		l.opts.report.Fields[i].Source = SourceZero
+	}
+}
@@ -0,0 +1,96 @@
+package envconfig
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLoadWith_Report sprawdza raport pochodzenia wartości pól
+func TestLoadWith_Report(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Name     string `envconfig:"env=APP_NAME,required"`
+		Port     int    `envconfig:"env=PORT,default=8080"`
+		Debug    bool   `envconfig:"env=DEBUG"`
+		Password string `envconfig:"env=DB_PASSWORD,file"`
+		Database struct {
+			Host string `envconfig:"env=HOST"`
+			User string `envconfig:"env=USER"`
+		} `envconfig:"prefix=DB_"`
+		Region string `envconfig:"env=REGION"`
+	}
+
+	dotenv := writeDotenv(t, "base.env", "DB_HOST=db.local\n")
+	passwordFile := writeSecret(t, "password", "s3cr3t\n")
+	source := Chain(
+		Named("flags", MapLookuper(map[string]string{"REGION": "eu"})),
+		MapLookuper(map[string]string{
+			"APP_NAME":         "demo",
+			"DB_PASSWORD_FILE": passwordFile,
+		}),
+	)
+
+	var report Report
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(source), WithDotenv(dotenv), WithReport(&report))
+	if err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+
+	want := []FieldReport{
+		{Path: "Name", EnvName: "APP_NAME", Source: SourceEnv, Required: true},
+		{Path: "Port", EnvName: "PORT", Source: SourceDefault},
+		{Path: "Debug", EnvName: "DEBUG", Source: SourceZero},
+		{Path: "Password", EnvName: "DB_PASSWORD", Source: passwordFile},
+		{Path: "Database.Host", EnvName: "DB_HOST", Source: dotenv},
+		{Path: "Database.User", EnvName: "DB_USER", Source: SourceZero},
+		{Path: "Region", EnvName: "REGION", Source: "flags"},
+	}
+	if !reflect.DeepEqual(report.Fields, want) {
+		t.Errorf("Report.Fields =\n%+v\nwant\n%+v", report.Fields, want)
+	}
+
+	field, ok := report.Field("Database.Host")
+	if !ok || field.Source != dotenv {
+		t.Errorf("Field(Database.Host) = (%+v, %v)", field, ok)
+	}
+	if _, ok := report.Field("Database"); ok {
+		t.Error("Field(Database) found a nested struct entry")
+	}
+
+	// Ponowne ładowanie zastępuje zawartość raportu, a pola z błędami są pomijane
+	err = LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"PORT": "abc"})), WithReport(&report))
+	if err == nil {
+		t.Fatal("LoadWith() error = nil, want error")
+	}
+	if _, ok := report.Field("Port"); ok {
+		t.Error("Field(Port) found a field that failed to load")
+	}
+	if _, ok := report.Field("Name"); ok {
+		t.Error("Field(Name) found a missing required field")
+	}
+	if field, ok := report.Field("Region"); !ok || field.Source != SourceZero {
+		t.Errorf("Field(Region) = (%+v, %v), want zero source", field, ok)
+	}
+}
+
+// TestDirLookuper_Source sprawdza, że wartości z katalogu są raportowane ze ścieżką pliku
+func TestDirLookuper_Source(t *testing.T) {
+	t.Parallel()
+
+	path := writeSecret(t, "TOKEN", "abc\n")
+
+	l, err := DirLookuper(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("DirLookuper() error = %v", err)
+	}
+	value, source, ok := lookupSource(l, "TOKEN")
+	if value != "abc" || source != path || !ok {
+		t.Errorf("lookupSource() = (%q, %q, %v), want (%q, %q, true)", value, source, ok, "abc", path)
+	}
+	if _, _, ok := lookupSource(l, "MISSING"); ok {
+		t.Error("lookupSource() found a missing key")
+	}
+}
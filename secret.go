@@ -0,0 +1,153 @@
+package envconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+)
+
+// SecretOf przechowuje wartość wrażliwą (hasło, token, klucz), która nigdy nie jest
+// wypisywana: String, GoString, formatowanie przez fmt (również %+v i %#v), MarshalJSON,
+// MarshalText i slog.LogValuer zwracają RedactedValue. Wartość jest dostępna wyłącznie
+// przez metodę Reveal.
+//
+// Pole typu SecretOf[T] jest ładowane tak jak pole typu T - np. SecretOf[int] parsuje
+// liczbę całkowitą. Błędy parsowania nie zawierają wartości (ParseError.Value jest ukrywane).
+// Klucze tagu dotyczące kolekcji (separator, kvSeparator) nie są stosowane do wartości
+// wewnątrz SecretOf - używane są separatory domyślne.
+type SecretOf[T any] struct {
+	value T
+}
+
+// Secret jest wrażliwą wartością tekstową - najczęściej używaną odmianą SecretOf
+type Secret = SecretOf[string]
+
+// NewSecret tworzy sekret z podaną wartością, np. na potrzeby testów
+func NewSecret[T any](value T) SecretOf[T] {
+	return SecretOf[T]{value: value}
+}
+
+// Reveal zwraca przechowywaną wartość. Wywołania tej metody są jedynym miejscem,
+// w którym wartość sekretu opuszcza typ, co ułatwia ich przegląd w kodzie.
+func (s SecretOf[T]) Reveal() T {
+	return s.value
+}
+
+// Decode implementuje interfejs Decoder
+func (s *SecretOf[T]) Decode(value string) error {
+	var v T
+	if err := setValue(reflect.ValueOf(&v).Elem(), value, "", nil); err != nil {
+		return redactError(err)
+	}
+	s.value = v
+	return nil
+}
+
+// String implementuje interfejs fmt.Stringer i zwraca RedactedValue
+func (s SecretOf[T]) String() string {
+	return RedactedValue
+}
+
+// GoString implementuje interfejs fmt.GoStringer i nie ujawnia wartości
+func (s SecretOf[T]) GoString() string {
+	return fmt.Sprintf("envconfig.SecretOf[%s]{%s}", reflect.TypeOf((*T)(nil)).Elem(), RedactedValue)
+}
+
+// Format implementuje interfejs fmt.Formatter, aby żaden czasownik formatowania
+// (również %d czy %x) nie ujawnił wartości
+func (s SecretOf[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, s.GoString())
+		return
+	}
+	fmt.Fprint(f, RedactedValue)
+}
+
+// MarshalJSON implementuje interfejs json.Marshaler i zwraca RedactedValue jako tekst JSON
+func (s SecretOf[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedValue)
+}
+
+// MarshalText implementuje interfejs encoding.TextMarshaler i zwraca RedactedValue
+func (s SecretOf[T]) MarshalText() ([]byte, error) {
+	return []byte(RedactedValue), nil
+}
+
+// LogValue implementuje interfejs slog.LogValuer i zwraca RedactedValue
+func (s SecretOf[T]) LogValue() slog.Value {
+	return slog.StringValue(RedactedValue)
+}
+
+// secret oznacza typy sekretów, aby można je było rozpoznać niezależnie od parametru typu
+func (s SecretOf[T]) secret() {}
+
+// secretMarker jest implementowany przez wszystkie odmiany SecretOf
+type secretMarker interface {
+	secret()
+}
+
+// secretMarkerType jest typem interfejsu secretMarker
+var secretMarkerType = reflect.TypeOf((*secretMarker)(nil)).Elem()
+
+// isSecretType sprawdza czy typ jest sekretem lub wskaźnikiem, listą albo mapą sekretów
+func isSecretType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		if isSecretType(t.Elem()) {
+			return true
+		}
+	}
+	return t.Implements(secretMarkerType)
+}
+
+// errRedacted zastępuje błędy parsowania, których komunikat mógłby ujawnić wartość sekretu
+var errRedacted = errors.New("invalid value")
+
+// errRedactedReference zastępuje błędy składni odwołań ${VAR}, których komunikat zawiera
+// fragment wartości sekretu
+var errRedactedReference = errors.New("invalid variable reference")
+
+// redactError zwraca przyczynę błędu parsowania pozbawioną wartości. Komunikaty błędów
+// strconv i time zawierają parsowaną wartość, dlatego z błędów strconv zachowywany jest
+// jedynie rodzaj błędu (strconv.ErrSyntax lub strconv.ErrRange), a pozostałe błędy
+// są zastępowane ogólnym komunikatem.
+func redactError(err error) error {
+	for _, safe := range []error{strconv.ErrSyntax, strconv.ErrRange} {
+		if errors.Is(err, safe) {
+			return safe
+		}
+	}
+	return errRedacted
+}
+
+// redactParseError ukrywa wartość w błędzie zwróconym przez setValue dla pola z sekretem
+func redactParseError(err error) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Value = RedactedValue
+		parseErr.Err = redactError(parseErr.Err)
+	}
+	return err
+}
+
+// redactInterpolationError ukrywa wartość w błędzie rozwijania odwołań dla pola z sekretem.
+// Komunikaty błędów składni odwołań (i komunikaty z ${VAR:?komunikat}) zawierają fragment
+// wartości, dlatego zachowywany jest jedynie rodzaj błędu (ErrReferenceCycle lub
+// ErrUnsetReference), a pozostałe błędy są zastępowane ogólnym komunikatem.
+func redactInterpolationError(err error) error {
+	var expandErr *InterpolationError
+	if !errors.As(err, &expandErr) {
+		return err
+	}
+	for _, safe := range []error{ErrReferenceCycle, ErrUnsetReference} {
+		if errors.Is(expandErr.Err, safe) {
+			expandErr.Err = safe
+			return err
+		}
+	}
+	expandErr.Err = errRedactedReference
+	return err
+}
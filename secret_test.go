@@ -0,0 +1,222 @@
+package envconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSecret_Redacted sprawdza, że żaden sposób wypisania sekretu nie ujawnia wartości
+func TestSecret_Redacted(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		User     string
+		Password Secret
+		Pin      SecretOf[int]
+	}
+	cfg := Config{User: "admin", Password: NewSecret("s3cr3t"), Pin: NewSecret(4321)}
+
+	outputs := map[string]string{
+		"%v":         fmt.Sprintf("%v", cfg),
+		"%+v":        fmt.Sprintf("%+v", cfg),
+		"%#v":        fmt.Sprintf("%#v", cfg),
+		"%s":         fmt.Sprintf("%s", cfg.Password),
+		"%d":         fmt.Sprintf("%d", cfg.Pin),
+		"%x":         fmt.Sprintf("%x", cfg.Password),
+		"String":     cfg.Password.String(),
+		"GoString":   cfg.Pin.GoString(),
+		"Sprint ptr": fmt.Sprint(&cfg.Password),
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	outputs["json"] = string(data)
+
+	text, err := cfg.Password.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error = %v", err)
+	}
+	outputs["text"] = string(text)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "config", cfg, "password", cfg.Password)
+	outputs["slog"] = buf.String()
+
+	for name, output := range outputs {
+		if strings.Contains(output, "s3cr3t") || strings.Contains(output, "4321") || strings.Contains(output, "10e1") {
+			t.Errorf("%s output leaked secret: %s", name, output)
+		}
+		if !strings.Contains(output, RedactedValue) {
+			t.Errorf("%s output = %q, want it to contain %q", name, output, RedactedValue)
+		}
+	}
+
+	if want := `{"User":"admin","Password":"[REDACTED]","Pin":"[REDACTED]"}`; outputs["json"] != want {
+		t.Errorf("json = %s, want %s", outputs["json"], want)
+	}
+	if want := "envconfig.SecretOf[int]{[REDACTED]}"; outputs["GoString"] != want {
+		t.Errorf("GoString() = %q, want %q", outputs["GoString"], want)
+	}
+	if cfg.Password.Reveal() != "s3cr3t" || cfg.Pin.Reveal() != 4321 {
+		t.Errorf("Reveal() = (%q, %d)", cfg.Password.Reveal(), cfg.Pin.Reveal())
+	}
+}
+
+// TestLoadWith_Secret sprawdza ładowanie sekretów różnych typów
+func TestLoadWith_Secret(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Password Secret                  `envconfig:"env=DB_PASSWORD,required"`
+		Port     SecretOf[int]           `envconfig:"env=PORT"`
+		TTL      SecretOf[time.Duration] `envconfig:"env=TTL,default=5m"`
+		Keys     []Secret                `envconfig:"env=KEYS"`
+		Token    *Secret                 `envconfig:"env=TOKEN"`
+		Missing  *Secret                 `envconfig:"env=MISSING"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"DB_PASSWORD": "s3cr3t",
+		"PORT":        "5432",
+		"KEYS":        "a,b",
+		"TOKEN":       "t0k3n",
+	})
+
+	var cfg Config
+	if err := LoadWith(&cfg, WithLookuper(source)); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if cfg.Password.Reveal() != "s3cr3t" || cfg.Port.Reveal() != 5432 || cfg.TTL.Reveal() != 5*time.Minute {
+		t.Errorf("got Password=%q Port=%d TTL=%v", cfg.Password.Reveal(), cfg.Port.Reveal(), cfg.TTL.Reveal())
+	}
+	if len(cfg.Keys) != 2 || cfg.Keys[1].Reveal() != "b" {
+		t.Errorf("Keys = %d elements", len(cfg.Keys))
+	}
+	if cfg.Token == nil || cfg.Token.Reveal() != "t0k3n" || cfg.Missing != nil {
+		t.Errorf("Token = %v, Missing = %v", cfg.Token, cfg.Missing)
+	}
+
+	desc, err := Describe(&cfg)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	for _, field := range desc {
+		wantValue := RedactedValue
+		if field.Path == "Missing" {
+			wantValue = ""
+		}
+		if !field.Secret || field.Value != wantValue {
+			t.Errorf("Describe() field %s = %+v, want secret with value %q", field.Path, field, wantValue)
+		}
+	}
+}
+
+// TestLoadWith_SecretParseError sprawdza, że błędy parsowania sekretów nie ujawniają wartości
+func TestLoadWith_SecretParseError(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Pin      SecretOf[int]           `envconfig:"env=PIN"`
+		TTL      SecretOf[time.Duration] `envconfig:"env=TTL"`
+		Code     uint8                   `envconfig:"env=CODE,secret"`
+		Visible  int                     `envconfig:"env=VISIBLE"`
+		Optional *SecretOf[bool]         `envconfig:"env=OPTIONAL"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"PIN":      "12ab34",
+		"TTL":      "sekret-ttl",
+		"CODE":     "-1",
+		"VISIBLE":  "abc",
+		"OPTIONAL": "maybe",
+	})
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(source))
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 5 {
+		t.Fatalf("LoadWith() error = %v, want 5 errors", err)
+	}
+
+	for _, secret := range []string{"12ab34", "sekret-ttl", "-1", "maybe"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("error leaked secret %q: %v", secret, err)
+		}
+	}
+	if !strings.Contains(err.Error(), "'abc'") {
+		t.Errorf("error = %v, want the non-secret value to be reported", err)
+	}
+
+	var parseErr *ParseError
+	if !errors.As(loadErrs[0], &parseErr) {
+		t.Fatalf("error type = %T, want *ParseError", loadErrs[0])
+	}
+	if parseErr.Value != RedactedValue || parseErr.FieldPath != "Pin" || parseErr.EnvName != "PIN" {
+		t.Errorf("ParseError = %+v", parseErr)
+	}
+	if !errors.Is(loadErrs[0], strconv.ErrSyntax) {
+		t.Errorf("error = %v, want strconv.ErrSyntax", loadErrs[0])
+	}
+	if !errors.Is(loadErrs[2], strconv.ErrSyntax) {
+		t.Errorf("error = %v, want strconv.ErrSyntax", loadErrs[2])
+	}
+}
+
+// TestLoadWith_SecretInterpolationError sprawdza, że błędy rozwijania odwołań w sekretach
+// nie ujawniają wartości
+func TestLoadWith_SecretInterpolationError(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Password Secret `envconfig:"env=PASSWORD,expand"`
+		Token    string `envconfig:"env=TOKEN,expand,secret"`
+		Key      Secret `envconfig:"env=KEY,expand"`
+		Visible  string `envconfig:"env=VISIBLE,expand"`
+	}
+
+	source := MapLookuper(map[string]string{
+		"PASSWORD": "pa${ss",
+		"TOKEN":    "${MISSING:?tok3n-hint}",
+		"KEY":      "k3y-${KEY}",
+		"VISIBLE":  "vis${ible",
+	})
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(source))
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 4 {
+		t.Fatalf("LoadWith() error = %v, want 4 errors", err)
+	}
+
+	for _, secret := range []string{"pa${ss", "tok3n-hint", "k3y-"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("error leaked secret %q: %v", secret, err)
+		}
+	}
+	if !strings.Contains(err.Error(), "vis${ible") {
+		t.Errorf("error = %v, want the non-secret value to be reported", err)
+	}
+
+	var expandErr *InterpolationError
+	if !errors.As(loadErrs[0], &expandErr) {
+		t.Fatalf("error type = %T, want *InterpolationError", loadErrs[0])
+	}
+	if expandErr.FieldPath != "Password" || expandErr.EnvName != "PASSWORD" {
+		t.Errorf("InterpolationError = %+v", expandErr)
+	}
+	if !errors.Is(loadErrs[1], ErrUnsetReference) {
+		t.Errorf("error = %v, want ErrUnsetReference", loadErrs[1])
+	}
+	if !errors.Is(loadErrs[2], ErrReferenceCycle) {
+		t.Errorf("error = %v, want ErrReferenceCycle", loadErrs[2])
+	}
+}
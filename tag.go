@@ -0,0 +1,195 @@
+package envconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// tagKeys opisuje klucze rozpoznawane w tagu envconfig.
+// Wartość true oznacza klucz logiczny, który może wystąpić bez wartości
+// (np. samo "required" jest skrótem od "required=true").
+var tagKeys = map[string]bool{
+	EnvKey:               false,
+	DefaultKey:           false,
+	RequiredKey:          true,
+	AllowEmptyKey:        true,
+	NotEmptyKey:          true,
+	SeparatorKey:         false,
+	KeyValueSeparatorKey: false,
+	PrefixKey:            false,
+	NamingKey:            false,
+	ExpandKey:            true,
+	FileKey:              true,
+	SecretKey:            true,
+	MinKey:               false,
+	MaxKey:               false,
+	LenKey:               false,
+	MinLenKey:            false,
+	MaxLenKey:            false,
+	OneOfKey:             false,
+	PatternKey:           false,
+	RequiredIfKey:        false,
+	RequiredUnlessKey:    false,
+	RequiredWithKey:      false,
+	ExcludedWithKey:      false,
+	GroupKey:             false,
+	ExclusiveKey:         true,
+	AtLeastOneKey:        true,
+	DeprecatedKey:        false,
+}
+
+// parseTag parsuje tag struktury w formacie "klucz1=wartość1,klucz2=wartość2"
+// i zwraca mapę par klucz-wartość.
+//
+// Gramatyka tagu:
+//   - pary są oddzielone przecinkami, a klucz od wartości - pierwszym znakiem "="
+//   - białe znaki wokół kluczy i wartości niecytowanych są usuwane
+//   - wartość w apostrofach ('a,b=c') jest brana dosłownie, wewnątrz niej "\'" oznacza
+//     apostrof, a "\\" pojedynczy "\"; pozostałe znaki "\" są zachowywane
+//   - w wartości niecytowanej "\," oznacza przecinek, a "\\" pojedynczy "\"; pozostałe znaki "\"
+//     są zachowywane
+//   - klucze logiczne mogą wystąpić bez wartości, co oznacza wartość "true"
+//
+// Nieznane lub powtórzone klucze, niezamknięte apostrofy i inne błędy składni
+// są zgłaszane jako *TagSyntaxError.
+func parseTag(tag string) (map[string]string, error) {
+	result := make(map[string]string)
+	if strings.TrimSpace(tag) == "" {
+		return result, nil
+	}
+
+	p := &tagParser{tag: tag}
+	for {
+		key, value, hasValue, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+
+		flag, known := tagKeys[key]
+		switch {
+		case key == "":
+			return nil, p.fail("missing key")
+		case !known:
+			return nil, p.fail("unknown key %q", key)
+		case hasValue && flag:
+			// Wartość klucza logicznego musi być poprawną wartością logiczną
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, p.fail("invalid boolean value %q for key %q", value, key)
+			}
+		case !hasValue && !flag:
+			return nil, p.fail("missing value for key %q", key)
+		case !hasValue:
+			value = "true"
+		}
+
+		if _, duplicate := result[key]; duplicate {
+			return nil, p.fail("duplicate key %q", key)
+		}
+		result[key] = value
+
+		// Po parze może wystąpić tylko przecinek rozpoczynający kolejną parę lub koniec tagu
+		if p.done() {
+			return result, nil
+		}
+		p.pos++
+		if p.done() {
+			return nil, p.fail("trailing comma")
+		}
+	}
+}
+
+// tagParser przechowuje stan parsowania pojedynczego tagu
+type tagParser struct {
+	tag string
+	pos int
+}
+
+// done sprawdza czy cały tag został przetworzony
+func (p *tagParser) done() bool {
+	return p.pos >= len(p.tag)
+}
+
+// fail tworzy błąd składni dla parsowanego tagu
+func (p *tagParser) fail(format string, args ...interface{}) *TagSyntaxError {
+	return newTagSyntaxError(p.tag, format, args...)
+}
+
+// next odczytuje kolejną parę klucz=wartość, zatrzymując się na kończącym ją przecinku
+func (p *tagParser) next() (key, value string, hasValue bool, err error) {
+	// Klucz kończy się znakiem "=" lub przecinkiem
+	start := p.pos
+	for !p.done() && p.tag[p.pos] != '=' && p.tag[p.pos] != ',' {
+		p.pos++
+	}
+	key = strings.TrimSpace(p.tag[start:p.pos])
+
+	if p.done() || p.tag[p.pos] == ',' {
+		return key, "", false, nil
+	}
+
+	// Pomijamy znak "=" i białe znaki przed wartością
+	p.pos++
+	for !p.done() && isTagSpace(p.tag[p.pos]) {
+		p.pos++
+	}
+
+	if !p.done() && p.tag[p.pos] == '\'' {
+		value, err = p.quoted()
+	} else {
+		value = p.unquoted()
+	}
+	if err != nil {
+		return "", "", false, err
+	}
+
+	// Po wartości w apostrofach może wystąpić tylko przecinek lub koniec tagu
+	if !p.done() && p.tag[p.pos] != ',' {
+		return "", "", false, p.fail("unexpected character %q after value of key %q", p.tag[p.pos], key)
+	}
+	return key, value, true, nil
+}
+
+// quoted odczytuje wartość w apostrofach wraz z białymi znakami po zamykającym apostrofie
+func (p *tagParser) quoted() (string, error) {
+	var b strings.Builder
+	p.pos++ // Otwierający apostrof
+
+	for !p.done() {
+		c := p.tag[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.tag) && (p.tag[p.pos+1] == '\'' || p.tag[p.pos+1] == '\\'):
+			b.WriteByte(p.tag[p.pos+1])
+			p.pos += 2
+		case c == '\'':
+			p.pos++
+			for !p.done() && isTagSpace(p.tag[p.pos]) {
+				p.pos++
+			}
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.fail("unterminated quoted value")
+}
+
+// unquoted odczytuje wartość bez apostrofów aż do przecinka niepoprzedzonego znakiem "\".
+// Tak jak w wartości w apostrofach, sekwencjami ucieczki są jedynie "\," i "\\" - pozostałe
+// znaki "\" są zachowywane, aby np. pattern=\d+ pozostało wyrażeniem regularnym.
+func (p *tagParser) unquoted() string {
+	var b strings.Builder
+	for !p.done() && p.tag[p.pos] != ',' {
+		if p.tag[p.pos] == '\\' && p.pos+1 < len(p.tag) && (p.tag[p.pos+1] == ',' || p.tag[p.pos+1] == '\\') {
+			p.pos++
+		}
+		b.WriteByte(p.tag[p.pos])
+		p.pos++
+	}
+	return strings.TrimRight(b.String(), " \t")
+}
+
+// isTagSpace sprawdza czy znak jest białym znakiem pomijanym w tagu
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -0,0 +1,241 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestParseTag sprawdza funkcję parseTag
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected map[string]string
+	}{
+		{
+			name:     "Empty tag",
+			tag:      "",
+			expected: map[string]string{},
+		},
+		{
+			name: "Single key-value pair",
+			tag:  "env=TEST_VAR",
+			expected: map[string]string{
+				"env": "TEST_VAR",
+			},
+		},
+		{
+			name: "Multiple key-value pairs",
+			tag:  "env=TEST_VAR,default=default value,required=true",
+			expected: map[string]string{
+				"env":      "TEST_VAR",
+				"default":  "default value",
+				"required": "true",
+			},
+		},
+		{
+			name: "Whitespace handling",
+			tag:  " env = TEST_VAR , default = default value ",
+			expected: map[string]string{
+				"env":     "TEST_VAR",
+				"default": "default value",
+			},
+		},
+		{
+			name: "Quoted value with comma and equals sign",
+			tag:  "default='a,b=c',env=TEST_VAR",
+			expected: map[string]string{
+				"default": "a,b=c",
+				"env":     "TEST_VAR",
+			},
+		},
+		{
+			name: "Quoted value keeps whitespace",
+			tag:  "default=' padded ' ,env=TEST_VAR",
+			expected: map[string]string{
+				"default": " padded ",
+				"env":     "TEST_VAR",
+			},
+		},
+		{
+			name: "Escapes in quoted value",
+			tag:  `default='it\'s \\ C:\dir'`,
+			expected: map[string]string{
+				"default": `it's \ C:\dir`,
+			},
+		},
+		{
+			name: "Escapes in unquoted value",
+			tag:  `separator=\,,default=a\\b`,
+			expected: map[string]string{
+				"separator": ",",
+				"default":   `a\b`,
+			},
+		},
+		{
+			name: "Other backslashes in unquoted value",
+			tag:  `pattern=\d+\.\w,default=C:\dir`,
+			expected: map[string]string{
+				"pattern": `\d+\.\w`,
+				"default": `C:\dir`,
+			},
+		},
+		{
+			name: "Equals sign in unquoted value",
+			tag:  "default=a=b",
+			expected: map[string]string{
+				"default": "a=b",
+			},
+		},
+		{
+			name: "Empty value",
+			tag:  "default=,env=TEST_VAR",
+			expected: map[string]string{
+				"default": "",
+				"env":     "TEST_VAR",
+			},
+		},
+		{
+			name: "Boolean key without value",
+			tag:  "env=TEST_VAR,required",
+			expected: map[string]string{
+				"env":      "TEST_VAR",
+				"required": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result, err := parseTag(tt.tag)
+				if err != nil {
+					t.Fatalf("parseTag() error = %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expected) {
+					t.Errorf("parseTag() = %v, want %v", result, tt.expected)
+				}
+			},
+		)
+	}
+}
+
+// TestParseTag_SyntaxErrors sprawdza zgłaszanie błędów składni tagu
+func TestParseTag_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantMsg string
+	}{
+		{name: "Missing value", tag: "env", wantMsg: `missing value for key "env"`},
+		{name: "Unknown key", tag: "env=TEST_VAR,invalid,default=value", wantMsg: `unknown key "invalid"`},
+		{name: "Unknown key with value", tag: "defualt=1", wantMsg: `unknown key "defualt"`},
+		{name: "Duplicate key", tag: "env=A,env=B", wantMsg: `duplicate key "env"`},
+		{name: "Unterminated quote", tag: "default='a,b", wantMsg: "unterminated quoted value"},
+		{name: "Text after quoted value", tag: "default='a'b", wantMsg: `unexpected character 'b' after value of key "default"`},
+		{name: "Invalid boolean", tag: "required=yes", wantMsg: `invalid boolean value "yes" for key "required"`},
+		{name: "Missing key", tag: "=value", wantMsg: "missing key"},
+		{name: "Empty item", tag: "env=A,,default=b", wantMsg: "missing key"},
+		{name: "Trailing comma", tag: "env=A,", wantMsg: "trailing comma"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				_, err := parseTag(tt.tag)
+
+				var tagErr *TagSyntaxError
+				if !errors.As(err, &tagErr) {
+					t.Fatalf("parseTag() error type = %T, want *TagSyntaxError", err)
+				}
+				if tagErr.Msg != tt.wantMsg {
+					t.Errorf("TagSyntaxError.Msg = %v, want %v", tagErr.Msg, tt.wantMsg)
+				}
+				if tagErr.Tag != tt.tag {
+					t.Errorf("TagSyntaxError.Tag = %v, want %v", tagErr.Tag, tt.tag)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_TagSyntaxError sprawdza, że błędny tag wskazuje pole i nie przerywa ładowania
+func TestLoadStruct_TagSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	type Nested struct {
+		Host string `envconfig:"env=HOST,required=maybe"`
+	}
+
+	type Config struct {
+		List   []string `envconfig:"env=LIST,default='a,b'"`
+		Broken string   `envconfig:"env=BROKEN,default='oops"`
+		Nested Nested
+	}
+
+	var cfg Config
+	err := LoadStruct(reflect.ValueOf(&cfg).Elem(), WithLookuper(MapLookuper(nil)))
+
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 2 {
+		t.Fatalf("LoadStruct() error = %v, want 2 tag errors", err)
+	}
+
+	var paths []string
+	for _, fieldErr := range loadErrs {
+		var tagErr *TagSyntaxError
+		if !errors.As(fieldErr, &tagErr) {
+			t.Fatalf("LoadStruct() error type = %T, want *TagSyntaxError", fieldErr)
+		}
+		paths = append(paths, tagErr.FieldPath)
+	}
+	if !reflect.DeepEqual(paths, []string{"Broken", "Nested.Host"}) {
+		t.Errorf("TagSyntaxError paths = %v, want %v", paths, []string{"Broken", "Nested.Host"})
+	}
+	if !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("LoadStruct() error does not match ErrInvalidTag")
+	}
+
+	// Poprawne pola są ładowane mimo błędnych tagów innych pól
+	if !reflect.DeepEqual(cfg.List, []string{"a", "b"}) {
+		t.Errorf("List = %v, want %v", cfg.List, []string{"a", "b"})
+	}
+}
+
+// TestLoadStruct_BooleanSpellings sprawdza, że każdy zapis wartości logicznej akceptowany
+// przez parser tagu jest respektowany przy ładowaniu
+func TestLoadStruct_BooleanSpellings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag          string
+		wantRequired bool
+	}{
+		{tag: "env=HOST,required", wantRequired: true},
+		{tag: "env=HOST,required=true", wantRequired: true},
+		{tag: "env=HOST,required=1", wantRequired: true},
+		{tag: "env=HOST,required=TRUE", wantRequired: true},
+		{tag: "env=HOST,required=t", wantRequired: true},
+		{tag: "env=HOST,required=0", wantRequired: false},
+		{tag: "env=HOST,required=False", wantRequired: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.tag, func(t *testing.T) {
+				t.Parallel()
+
+				structType := reflect.StructOf([]reflect.StructField{
+					{Name: "Host", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`envconfig:"` + tt.tag + `"`)},
+				})
+				err := LoadStruct(reflect.New(structType).Elem(), WithLookuper(MapLookuper(nil)))
+
+				var reqErr *RequiredFieldError
+				if got := errors.As(err, &reqErr); got != tt.wantRequired {
+					t.Errorf("LoadStruct() error = %v, want RequiredFieldError: %v", err, tt.wantRequired)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,256 @@
+package envconfig
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ruleKeys zawiera klucze tagu będące regułami walidacji, w kolejności ich sprawdzania
+var ruleKeys = []string{MinKey, MaxKey, LenKey, MinLenKey, MaxLenKey, OneOfKey, PatternKey}
+
+// rule jest pojedynczą regułą walidacji z tagu pola
+type rule struct {
+	key   string                   // Klucz tagu, np. "max"
+	arg   string                   // Argument reguły z tagu, np. "65535"
+	each  bool                     // Czy reguła dotyczy każdego elementu listy, a nie całej wartości
+	check func(reflect.Value) bool // Zwraca true, jeśli wartość spełnia regułę
+}
+
+// String zwraca regułę w postaci, w jakiej występuje w tagu
+func (r rule) String() string {
+	return r.key + "=" + r.arg
+}
+
+// parseRules tworzy reguły walidacji z tagu pola. Reguły są przygotowywane niezależnie
+// od tego, czy pole otrzyma wartość, dzięki czemu niepoprawne reguły (np. min dla tekstu
+// lub niepoprawne wyrażenie regularne) są zgłaszane zawsze.
+//
+//   - min, max - liczby całkowite, zmiennoprzecinkowe i time.Duration
+//   - len, minlen, maxlen - długość tekstu (w znakach), listy, tablicy lub mapy
+//   - oneof - dozwolone wartości oddzielone znakiem "|"; dla list dotyczy każdego elementu
+//   - pattern - wyrażenie regularne, do którego musi pasować cała wartość tekstowa;
+//     dla list tekstów dotyczy każdego elementu
+//
+// Reguły dotyczą wartości wskazywanej przez wskaźnik oraz wartości przechowywanej w SecretOf.
+func parseRules(t reflect.Type, tagMap map[string]string) ([]rule, error) {
+	t = validatedType(t)
+
+	var rules []rule
+	for _, key := range ruleKeys {
+		arg, ok := tagMap[key]
+		if !ok {
+			continue
+		}
+
+		r := rule{key: key, arg: arg}
+		var err error
+		switch key {
+		case MinKey, MaxKey:
+			r.check, err = boundCheck(t, key, arg)
+		case LenKey, MinLenKey, MaxLenKey:
+			r.check, err = lengthCheck(t, key, arg)
+		case OneOfKey, PatternKey:
+			elem := t
+			if isListType(t) {
+				elem = validatedType(t.Elem())
+				r.each = true
+			}
+			if key == OneOfKey {
+				r.check, err = oneOfCheck(elem, key, arg)
+			} else {
+				r.check, err = patternCheck(elem, arg)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// checkRules sprawdza reguły dla ustawionej wartości pola i zwraca błędy *ValidationError.
+// Wartość sekretu jest w błędach zastępowana przez RedactedValue.
+func checkRules(field reflect.Value, fieldName string, rules []rule, tagMap map[string]string, secret bool) []error {
+	value, ok := validatedValue(field)
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, r := range rules {
+		if !r.each {
+			if !r.check(value) {
+				errs = append(errs, newValidationError(value, fieldName, r, tagMap, secret))
+			}
+			continue
+		}
+		for i := 0; i < value.Len(); i++ {
+			elem, ok := validatedValue(value.Index(i))
+			if ok && !r.check(elem) {
+				errs = append(errs, newValidationError(elem, fmt.Sprintf("%s[%d]", fieldName, i), r, nil, secret))
+			}
+		}
+	}
+	return errs
+}
+
+// newValidationError tworzy błąd niespełnionej reguły dla wartości
+func newValidationError(value reflect.Value, fieldName string, r rule, tagMap map[string]string, secret bool) error {
+	text := formatValue(value, tagMap)
+	if secret {
+		text = RedactedValue
+	}
+	return &ValidationError{
+		FieldName: fieldName,
+		Rule:      r.String(),
+		Value:     text,
+	}
+}
+
+// validatedType zwraca typ wartości, której dotyczą reguły: typ wskazywany przez wskaźnik
+// lub typ wartości przechowywanej w SecretOf
+func validatedType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(secretMarkerType) {
+		if reveal, ok := t.MethodByName("Reveal"); ok {
+			return reveal.Type.Out(0)
+		}
+	}
+	return t
+}
+
+// validatedValue zwraca wartość, której dotyczą reguły (zob. validatedType),
+// lub false dla wskaźnika równego nil
+func validatedValue(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(secretMarkerType) {
+		v = v.MethodByName("Reveal").Call(nil)[0]
+	}
+	return v, true
+}
+
+// isListType sprawdza czy reguły oneof i pattern należy stosować do elementów typu
+func isListType(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && !implementsDecoder(t)
+}
+
+// boundCheck tworzy regułę min lub max
+func boundCheck(t reflect.Type, key string, arg string) (func(reflect.Value) bool, error) {
+	var compare func(reflect.Value) int
+	var err error
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var bound int64
+		if t == reflect.TypeOf(time.Duration(0)) {
+			var d time.Duration
+			d, err = time.ParseDuration(arg)
+			bound = int64(d)
+		} else {
+			bound, err = strconv.ParseInt(arg, 10, 64)
+		}
+		compare = func(v reflect.Value) int { return cmp.Compare(v.Int(), bound) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var bound uint64
+		bound, err = strconv.ParseUint(arg, 10, 64)
+		compare = func(v reflect.Value) int { return cmp.Compare(v.Uint(), bound) }
+	case reflect.Float32, reflect.Float64:
+		var bound float64
+		bound, err = strconv.ParseFloat(arg, 64)
+		compare = func(v reflect.Value) int { return cmp.Compare(v.Float(), bound) }
+	default:
+		return nil, fmt.Errorf("key %q is not supported for type %s", key, t)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for key %q: %v", arg, key, err)
+	}
+
+	if key == MinKey {
+		return func(v reflect.Value) bool { return compare(v) >= 0 }, nil
+	}
+	return func(v reflect.Value) bool { return compare(v) <= 0 }, nil
+}
+
+// lengthCheck tworzy regułę len, minlen lub maxlen
+func lengthCheck(t reflect.Type, key string, arg string) (func(reflect.Value) bool, error) {
+	var length func(reflect.Value) int
+	switch t.Kind() {
+	case reflect.String:
+		length = func(v reflect.Value) int { return utf8.RuneCountInString(v.String()) }
+	case reflect.Slice, reflect.Array, reflect.Map:
+		length = reflect.Value.Len
+	default:
+		return nil, fmt.Errorf("key %q is not supported for type %s", key, t)
+	}
+
+	bound, err := strconv.Atoi(arg)
+	if err != nil || bound < 0 {
+		return nil, fmt.Errorf("invalid value %q for key %q: must be a non-negative integer", arg, key)
+	}
+
+	switch key {
+	case MinLenKey:
+		return func(v reflect.Value) bool { return length(v) >= bound }, nil
+	case MaxLenKey:
+		return func(v reflect.Value) bool { return length(v) <= bound }, nil
+	}
+	return func(v reflect.Value) bool { return length(v) == bound }, nil
+}
+
+// oneOfCheck tworzy regułę oneof (lub warunek required_if i required_unless dla klucza key).
+// Dozwolone wartości są parsowane tak jak wartość pola, więc porównywane są wartości,
+// a nie ich zapis (np. "08" i "8" dla liczb).
+func oneOfCheck(t reflect.Type, key string, arg string) (func(reflect.Value) bool, error) {
+	if !t.Comparable() || t.Kind() == reflect.Interface {
+		return nil, fmt.Errorf("key %q is not supported for type %s", key, t)
+	}
+
+	var allowed []interface{}
+	for _, option := range strings.Split(arg, "|") {
+		value := reflect.New(t).Elem()
+		if err := setFieldValue(value, strings.TrimSpace(option), ""); err != nil {
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) {
+				err = parseErr.Err
+			}
+			return nil, fmt.Errorf("invalid value %q for key %q: %v", option, key, err)
+		}
+		allowed = append(allowed, value.Interface())
+	}
+
+	return func(v reflect.Value) bool {
+		for _, option := range allowed {
+			if v.Interface() == option {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// patternCheck tworzy regułę pattern - wyrażenie regularne musi pasować do całej wartości
+func patternCheck(t reflect.Type, arg string) (func(reflect.Value) bool, error) {
+	if t.Kind() != reflect.String {
+		return nil, fmt.Errorf("key %q is not supported for type %s", PatternKey, t)
+	}
+	re, err := regexp.Compile(`^(?:` + arg + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for key %q: %v", arg, PatternKey, err)
+	}
+	return func(v reflect.Value) bool { return re.MatchString(v.String()) }, nil
+}
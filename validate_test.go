@@ -0,0 +1,231 @@
+package envconfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validateConfig jest konfiguracją z regułami walidacji używaną w testach
+type validateConfig struct {
+	Port     int               `envconfig:"env=PORT,min=1,max=65535"`
+	Ratio    float64           `envconfig:"env=RATIO,min=0,max=1"`
+	Workers  uint              `envconfig:"env=WORKERS,max=64"`
+	Timeout  time.Duration     `envconfig:"env=TIMEOUT,min=1s,max=1m"`
+	Name     string            `envconfig:"env=NAME,minlen=3,maxlen=8"`
+	Code     string            `envconfig:"env=CODE,len=2"`
+	Hosts    []string          `envconfig:"env=HOSTS,minlen=1,maxlen=3"`
+	Labels   map[string]string `envconfig:"env=LABELS,maxlen=2"`
+	Level    string            `envconfig:"env=LEVEL,oneof=debug|info|warn|error"`
+	Retries  int               `envconfig:"env=RETRIES,oneof=1|3|5"`
+	Regions  []string          `envconfig:"env=REGIONS,oneof=eu|us"`
+	Version  string            `envconfig:"env=VERSION,pattern='v[0-9]+(\\.[0-9]+){0,2}'"`
+	Build    string            `envconfig:"env=BUILD,pattern=\\d+"`
+	Replicas *int              `envconfig:"env=REPLICAS,min=1"`
+	Token    Secret            `envconfig:"env=TOKEN,minlen=8"`
+}
+
+// TestLoadStruct_Validation sprawdza poprawne wartości i wartości naruszające reguły
+func TestLoadStruct_Validation(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string]string{
+		"PORT":     "8080",
+		"RATIO":    "0.5",
+		"WORKERS":  "64",
+		"TIMEOUT":  "30s",
+		"NAME":     "zażółć",
+		"CODE":     "pl",
+		"HOSTS":    "a,b",
+		"LABELS":   "a:1",
+		"LEVEL":    "info",
+		"RETRIES":  "03",
+		"REGIONS":  "eu,us,eu",
+		"VERSION":  "v1.2",
+		"BUILD":    "123",
+		"REPLICAS": "2",
+		"TOKEN":    "long-enough",
+	}
+
+	var cfg validateConfig
+	if err := LoadWith(&cfg, WithLookuper(MapLookuper(valid))); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+
+	// Pola bez wartości nie są walidowane
+	var empty validateConfig
+	if err := LoadWith(&empty, WithLookuper(MapLookuper(nil))); err != nil {
+		t.Fatalf("LoadWith() with no values error = %v", err)
+	}
+
+	tests := []struct {
+		key       string
+		value     string
+		wantPath  string
+		wantRule  string
+		wantValue string
+	}{
+		{key: "PORT", value: "0", wantPath: "Port", wantRule: "min=1", wantValue: "0"},
+		{key: "PORT", value: "70000", wantPath: "Port", wantRule: "max=65535", wantValue: "70000"},
+		{key: "RATIO", value: "1.5", wantPath: "Ratio", wantRule: "max=1", wantValue: "1.5"},
+		{key: "WORKERS", value: "65", wantPath: "Workers", wantRule: "max=64", wantValue: "65"},
+		{key: "TIMEOUT", value: "500ms", wantPath: "Timeout", wantRule: "min=1s", wantValue: "500ms"},
+		{key: "TIMEOUT", value: "2m", wantPath: "Timeout", wantRule: "max=1m", wantValue: "2m0s"},
+		{key: "NAME", value: "ab", wantPath: "Name", wantRule: "minlen=3", wantValue: "ab"},
+		{key: "NAME", value: "abcdefghi", wantPath: "Name", wantRule: "maxlen=8", wantValue: "abcdefghi"},
+		{key: "CODE", value: "pol", wantPath: "Code", wantRule: "len=2", wantValue: "pol"},
+		{key: "HOSTS", value: "", wantPath: "Hosts", wantRule: "minlen=1", wantValue: ""},
+		{key: "HOSTS", value: "a,b,c,d", wantPath: "Hosts", wantRule: "maxlen=3", wantValue: "a,b,c,d"},
+		{key: "LABELS", value: "a:1,b:2,c:3", wantPath: "Labels", wantRule: "maxlen=2", wantValue: "a:1,b:2,c:3"},
+		{key: "LEVEL", value: "trace", wantPath: "Level", wantRule: "oneof=debug|info|warn|error", wantValue: "trace"},
+		{key: "RETRIES", value: "2", wantPath: "Retries", wantRule: "oneof=1|3|5", wantValue: "2"},
+		{key: "REGIONS", value: "eu,asia", wantPath: "Regions[1]", wantRule: "oneof=eu|us", wantValue: "asia"},
+		{key: "VERSION", value: "1.2", wantPath: "Version", wantRule: `pattern=v[0-9]+(\.[0-9]+){0,2}`, wantValue: "1.2"},
+		{key: "VERSION", value: "v1.2-rc", wantPath: "Version", wantRule: `pattern=v[0-9]+(\.[0-9]+){0,2}`, wantValue: "v1.2-rc"},
+		{key: "BUILD", value: "ddd", wantPath: "Build", wantRule: `pattern=\d+`, wantValue: "ddd"},
+		{key: "REPLICAS", value: "0", wantPath: "Replicas", wantRule: "min=1", wantValue: "0"},
+		{key: "TOKEN", value: "short", wantPath: "Token", wantRule: "minlen=8", wantValue: RedactedValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.key+"="+tt.value, func(t *testing.T) {
+				t.Parallel()
+
+				values := make(map[string]string, len(valid))
+				for key, value := range valid {
+					values[key] = value
+				}
+				values[tt.key] = tt.value
+
+				var cfg validateConfig
+				err := LoadWith(&cfg, WithLookuper(MapLookuper(values)), WithAllowEmpty())
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("LoadWith() error type = %T (%v), want *ValidationError", err, err)
+				}
+				if validationErr.FieldPath != tt.wantPath || validationErr.EnvName != tt.key ||
+					validationErr.Rule != tt.wantRule || validationErr.Value != tt.wantValue {
+					t.Errorf("ValidationError = %+v, want path %s, rule %s, value %q", validationErr, tt.wantPath, tt.wantRule, tt.wantValue)
+				}
+				if !errors.Is(err, ErrValidation) {
+					t.Error("error does not match ErrValidation")
+				}
+				if tt.key == "TOKEN" && strings.Contains(err.Error(), tt.value) {
+					t.Errorf("error leaked secret: %v", err)
+				}
+			},
+		)
+	}
+}
+
+// TestLoadStruct_ValidationDefaults sprawdza, że wartości domyślne również podlegają walidacji
+func TestLoadStruct_ValidationDefaults(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Server struct {
+			Port int `envconfig:"env=PORT,default=0,min=1"`
+		}
+	}
+
+	var cfg Config
+	err := LoadWith(&cfg, WithLookuper(MapLookuper(nil)))
+	want := "validation failed for field 'Server.Port' (env: PORT): value '0' does not satisfy min=1"
+	if err == nil || err.Error() != want {
+		t.Errorf("LoadWith() error = %v, want %s", err, want)
+	}
+}
+
+// TestLoadStruct_InvalidRules sprawdza, że niepoprawne reguły są błędami tagu
+func TestLoadStruct_InvalidRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  interface{}
+		wantMsg string
+	}{
+		{
+			name: "min for string",
+			config: &struct {
+				Field string `envconfig:"min=1"`
+			}{},
+			wantMsg: `key "min" is not supported for type string`,
+		},
+		{
+			name: "invalid bound",
+			config: &struct {
+				Field int `envconfig:"max=ten"`
+			}{},
+			wantMsg: `invalid value "ten" for key "max"`,
+		},
+		{
+			name: "invalid duration",
+			config: &struct {
+				Field time.Duration `envconfig:"min=5"`
+			}{},
+			wantMsg: `invalid value "5" for key "min"`,
+		},
+		{
+			name: "negative length",
+			config: &struct {
+				Field []int `envconfig:"len=-1"`
+			}{},
+			wantMsg: "must be a non-negative integer",
+		},
+		{
+			name: "length for number",
+			config: &struct {
+				Field int `envconfig:"maxlen=3"`
+			}{},
+			wantMsg: `key "maxlen" is not supported for type int`,
+		},
+		{
+			name: "invalid oneof option",
+			config: &struct {
+				Field int `envconfig:"oneof=1|two"`
+			}{},
+			wantMsg: `invalid value "two" for key "oneof"`,
+		},
+		{
+			name: "oneof for map",
+			config: &struct {
+				Field map[string]int `envconfig:"oneof=a"`
+			}{},
+			wantMsg: `key "oneof" is not supported for type map[string]int`,
+		},
+		{
+			name: "invalid pattern",
+			config: &struct {
+				Field string `envconfig:"pattern=[a-"`
+			}{},
+			wantMsg: `invalid value "[a-" for key "pattern"`,
+		},
+		{
+			name: "pattern for number",
+			config: &struct {
+				Field []int `envconfig:"pattern=1"`
+			}{},
+			wantMsg: `key "pattern" is not supported for type int`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := LoadWith(tt.config, WithLookuper(MapLookuper(nil)))
+				var tagErr *TagSyntaxError
+				if !errors.As(err, &tagErr) {
+					t.Fatalf("LoadWith() error type = %T, want *TagSyntaxError", err)
+				}
+				if tagErr.FieldPath != "Field" || !strings.Contains(tagErr.Msg, tt.wantMsg) {
+					t.Errorf("TagSyntaxError = %+v, want message containing %q", tagErr, tt.wantMsg)
+				}
+			},
+		)
+	}
+}
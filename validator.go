@@ -0,0 +1,53 @@
+package envconfig
+
+import "reflect"
+
+// Validator jest interfejsem dla struktur konfiguracyjnych, które same sprawdzają
+// reguły obejmujące kilka pól, np. "TLSCert wymaga TLSKey". Metoda Validate jest
+// wywoływana po załadowaniu struktury - najpierw dla struktur zagnieżdżonych,
+// a potem dla struktury nadrzędnej.
+type Validator interface {
+	Validate() error
+}
+
+// loadValidated ładuje strukturę, a następnie wywołuje jej metodę Validate.
+// Validate nie jest wywoływana, jeśli ładowanie pól struktury (lub jej struktur
+// zagnieżdżonych) zakończyło się błędem, bo reguły sprawdzałyby niekompletne wartości,
+// ani - dla nowo alokowanej struktury wskaźnikowej (optional) - jeśli żadne pole
+// nie otrzymało wartości, bo wskaźnik pozostanie nil. Błędy Validate struktur
+// zagnieżdżonych nie wstrzymują walidacji struktury nadrzędnej.
+func (l *loader) loadValidated(structValue reflect.Value, sc scope, optional bool) bool {
+	before := l.fieldErrors()
+	provided := l.loadStruct(structValue, sc)
+	if l.fieldErrors() == before && (provided || !optional) {
+		l.validateStruct(structValue, sc.path)
+	}
+	return provided
+}
+
+// validateStruct wywołuje metodę Validate struktury (z odbiorcą wartości lub wskaźnika),
+// a zwrócony błąd zapisuje w loaderze opakowany w StructValidationError
+func (l *loader) validateStruct(structValue reflect.Value, path string) {
+	candidate := structValue
+	if structValue.CanAddr() {
+		candidate = structValue.Addr()
+	}
+	validator, ok := candidate.Interface().(Validator)
+	if !ok {
+		return
+	}
+	if err := validator.Validate(); err != nil {
+		l.fail(&StructValidationError{FieldPath: path, Err: err})
+	}
+}
+
+// fieldErrors zwraca liczbę zebranych błędów ładowania pól, z pominięciem błędów Validate
+func (l *loader) fieldErrors() int {
+	count := 0
+	for _, err := range l.errors {
+		if _, ok := err.(*StructValidationError); !ok {
+			count++
+		}
+	}
+	return count
+}
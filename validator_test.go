@@ -0,0 +1,174 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// errTLSKey jest błędem zwracanym przez tlsConfig.Validate
+var errTLSKey = errors.New("TLSCert requires TLSKey")
+
+// tlsConfig implementuje Validator z odbiorcą wskaźnikowym
+type tlsConfig struct {
+	Cert string `envconfig:"env=CERT"`
+	Key  string `envconfig:"env=KEY"`
+}
+
+// Validate sprawdza, że certyfikat jest podany razem z kluczem
+func (c *tlsConfig) Validate() error {
+	if c.Cert != "" && c.Key == "" {
+		return errTLSKey
+	}
+	return nil
+}
+
+// limitsConfig implementuje Validator z odbiorcą wartości
+type limitsConfig struct {
+	Min int `envconfig:"env=MIN"`
+	Max int `envconfig:"env=MAX"`
+}
+
+// Validate sprawdza kolejność granic
+func (c limitsConfig) Validate() error {
+	if c.Min > c.Max {
+		return errors.New("MIN must not exceed MAX")
+	}
+	return nil
+}
+
+// serverConfig zapisuje kolejność wywołań Validate w zagnieżdżonych strukturach
+type serverConfig struct {
+	TLS    tlsConfig    `envconfig:"prefix=TLS_"`
+	Limits limitsConfig `envconfig:"prefix=LIMITS_"`
+	Port   int          `envconfig:"env=PORT"`
+	calls  *[]string
+}
+
+// Validate sprawdza pola struktury głównej i zapisuje wywołanie
+func (c *serverConfig) Validate() error {
+	if c.calls != nil {
+		*c.calls = append(*c.calls, "server")
+	}
+	if c.Port == 0 {
+		return errors.New("PORT must be set")
+	}
+	return nil
+}
+
+// TestLoadStruct_Validator sprawdza wywołanie Validate dla struktur głównych i zagnieżdżonych
+func TestLoadStruct_Validator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		values    map[string]string
+		wantPaths []string
+	}{
+		{
+			name:   "valid",
+			values: map[string]string{"PORT": "8080", "TLS_CERT": "cert.pem", "TLS_KEY": "key.pem", "LIMITS_MAX": "1"},
+		},
+		{
+			name:      "nested errors",
+			values:    map[string]string{"PORT": "8080", "TLS_CERT": "cert.pem", "LIMITS_MIN": "2", "LIMITS_MAX": "1"},
+			wantPaths: []string{"TLS", "Limits"},
+		},
+		{
+			name:      "top-level error",
+			values:    map[string]string{"TLS_CERT": "cert.pem", "TLS_KEY": "key.pem"},
+			wantPaths: []string{""},
+		},
+		{
+			name:      "nested and top-level errors",
+			values:    map[string]string{"TLS_CERT": "cert.pem"},
+			wantPaths: []string{"TLS", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var cfg serverConfig
+				err := LoadWith(&cfg, WithLookuper(MapLookuper(tt.values)))
+				if len(tt.wantPaths) == 0 {
+					if err != nil {
+						t.Fatalf("LoadWith() error = %v", err)
+					}
+					return
+				}
+
+				var loadErrs LoadErrors
+				if !errors.As(err, &loadErrs) {
+					t.Fatalf("LoadWith() error type = %T, want LoadErrors", err)
+				}
+				var paths []string
+				for _, fieldErr := range loadErrs {
+					var structErr *StructValidationError
+					if !errors.As(fieldErr, &structErr) {
+						t.Fatalf("error type = %T, want *StructValidationError", fieldErr)
+					}
+					paths = append(paths, structErr.FieldPath)
+				}
+				if !reflect.DeepEqual(paths, tt.wantPaths) {
+					t.Errorf("error paths = %q, want %q", paths, tt.wantPaths)
+				}
+				if !errors.Is(err, ErrValidation) {
+					t.Error("error does not match ErrValidation")
+				}
+			},
+		)
+	}
+
+	var cfg serverConfig
+	err := LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"PORT": "1", "TLS_CERT": "cert.pem"})))
+	if !errors.Is(err, errTLSKey) {
+		t.Errorf("LoadWith() error = %v, want errTLSKey", err)
+	}
+	want := "validation failed for struct 'TLS': TLSCert requires TLSKey"
+	if err == nil || err.Error() != want {
+		t.Errorf("LoadWith() error = %v, want %s", err, want)
+	}
+}
+
+// TestLoadStruct_ValidatorSkipped sprawdza, kiedy Validate nie jest wywoływana
+func TestLoadStruct_ValidatorSkipped(t *testing.T) {
+	t.Parallel()
+
+	// Błąd ładowania pola przerywa walidację struktury i struktur nadrzędnych
+	var calls []string
+	cfg := serverConfig{calls: &calls}
+	err := LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"PORT": "abc", "LIMITS_MIN": "x"})))
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 2 {
+		t.Fatalf("LoadWith() error = %v, want 2 parse errors", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Validate() called %d times, want 0", len(calls))
+	}
+
+	// Wskaźnik do struktury bez żadnej wartości pozostaje nil i nie jest walidowany
+	type Config struct {
+		TLS *tlsConfig `envconfig:"prefix=TLS_"`
+	}
+	var optional Config
+	if err := LoadWith(&optional, WithLookuper(MapLookuper(nil))); err != nil || optional.TLS != nil {
+		t.Fatalf("LoadWith() = %v, TLS = %v", err, optional.TLS)
+	}
+	err = LoadWith(&optional, WithLookuper(MapLookuper(map[string]string{"TLS_CERT": "cert.pem"})))
+	if !errors.Is(err, errTLSKey) {
+		t.Errorf("LoadWith() error = %v, want errTLSKey", err)
+	}
+
+	// Validate jest wywoływana dokładnie raz dla struktury głównej
+	calls = nil
+	cfg = serverConfig{calls: &calls}
+	if err := LoadWith(&cfg, WithLookuper(MapLookuper(map[string]string{"PORT": "1"}))); err != nil {
+		t.Fatalf("LoadWith() error = %v", err)
+	}
+	if !reflect.DeepEqual(calls, []string{"server"}) {
+		t.Errorf("Validate() calls = %q", calls)
+	}
+}